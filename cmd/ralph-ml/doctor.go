@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/config"
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/display"
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/orchestrator"
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/pyenv"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorConfig   string
+	doctorFix      bool
+	doctorVenv     string
+	doctorLockfile string
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that the Python environment is ready to run Ralph ML Loop",
+	Long: `Verify the Python interpreter version and the required packages (ralph_ml and the configured framework) are importable, printing actionable remediation for anything missing.
+
+With --fix, provision a virtual environment (creating it and installing
+--lockfile into it with pip if it doesn't already exist) before checking,
+instead of only diagnosing what's missing.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfigForDoctor()
+		if err != nil {
+			return err
+		}
+
+		pythonPath, err := orchestrator.DetectPythonPath(cfg.Execution.Python)
+		if err != nil {
+			display.PrintPythonNotFound()
+			return fmt.Errorf("python detection failed: %w", err)
+		}
+
+		if doctorFix {
+			display.Info(fmt.Sprintf("Provisioning virtual environment at %s", doctorVenv))
+			if err := pyenv.EnsureVenv(pythonPath, doctorVenv, doctorLockfile); err != nil {
+				return fmt.Errorf("failed to provision virtual environment: %w", err)
+			}
+			pythonPath = pyenv.VenvPythonPath(doctorVenv)
+			display.Success(fmt.Sprintf("Using provisioned environment: %s", pythonPath))
+		}
+
+		result, err := pyenv.CheckEnvironment(pythonPath, pyenv.RequiredModules(cfg.Project.Framework))
+		if err != nil {
+			display.PrintPythonNotFound()
+			return fmt.Errorf("environment check failed: %w", err)
+		}
+
+		display.Info(fmt.Sprintf("Python: %s (%s)", result.PythonVersion, result.PythonPath))
+
+		if !result.MeetsMinVersion {
+			display.Error(fmt.Sprintf("Python %d.%d or newer is required", pyenv.MinPythonVersion[0], pyenv.MinPythonVersion[1]))
+		} else {
+			display.Success("Python version OK")
+		}
+
+		if len(result.MissingModules) > 0 {
+			display.Error(fmt.Sprintf("Missing packages: %s", strings.Join(result.MissingModules, ", ")))
+			cmd.Println("To fix this, install the missing packages, e.g.:")
+			cmd.Printf("  %s -m pip install %s\n", pythonPath, strings.Join(result.MissingModules, " "))
+		} else {
+			display.Success("All required packages are importable")
+		}
+
+		if !result.OK() {
+			return fmt.Errorf("environment check failed")
+		}
+
+		display.Success("Environment looks good!")
+		return nil
+	},
+}
+
+func init() {
+	doctorCmd.Flags().StringVarP(&doctorConfig, "config", "c", "", "Path to config file")
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Provision a virtual environment before checking, instead of only diagnosing")
+	doctorCmd.Flags().StringVar(&doctorVenv, "venv", "./.venv", "Virtual environment directory to provision with --fix")
+	doctorCmd.Flags().StringVar(&doctorLockfile, "lockfile", "requirements.txt", "Requirements file to install into the virtual environment with --fix")
+}
+
+// loadConfigForDoctor loads configuration for the doctor command, matching
+// loadConfigForStart's resolution order without requiring a run directory.
+func loadConfigForDoctor() (*config.Config, error) {
+	var cfg *config.Config
+
+	if doctorConfig != "" {
+		var err error
+		cfg, err = config.LoadConfig(doctorConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config: %w", err)
+		}
+	} else if _, err := os.Stat("RALPH_ML_CONFIG.json"); err == nil {
+		cfg, err = config.LoadConfig("RALPH_ML_CONFIG.json")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load default config: %w", err)
+		}
+	} else {
+		cfg = config.NewDefaultConfig()
+	}
+
+	config.ApplyEnvOverrides(cfg)
+	return cfg, nil
+}
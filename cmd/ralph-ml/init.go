@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/matthiaspetry/DeepLoop/cli/pkg/config"
@@ -9,6 +10,7 @@ import (
 
 var (
 	initConfig string
+	initFormat string
 )
 
 var initCmd = &cobra.Command{
@@ -16,9 +18,21 @@ var initCmd = &cobra.Command{
 	Short: "Initialize a new Ralph ML Loop project",
 	Long:  `Initialize a new Ralph ML Loop project by creating a default config file.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		var format config.Format
+		switch initFormat {
+		case "", "json":
+			format = config.FormatJSON
+		case "toml":
+			format = config.FormatTOML
+		case "yaml":
+			format = config.FormatYAML
+		default:
+			return fmt.Errorf("unsupported format: %s (expected json, toml, or yaml)", initFormat)
+		}
+
 		configPath := initConfig
 		if configPath == "" {
-			configPath = "RALPH_ML_CONFIG.json"
+			configPath = "RALPH_ML_CONFIG." + string(format)
 		}
 
 		// Check if config already exists
@@ -31,7 +45,7 @@ var initCmd = &cobra.Command{
 		defaultConfig := config.NewDefaultConfig()
 
 		// Save config
-		if err := config.SaveConfig(configPath, defaultConfig); err != nil {
+		if err := config.SaveConfigFormat(configPath, defaultConfig, format); err != nil {
 			return err
 		}
 
@@ -44,5 +58,10 @@ var initCmd = &cobra.Command{
 }
 
 func init() {
-	initCmd.Flags().StringVarP(&initConfig, "config", "c", "", "Path to config file (default: RALPH_ML_CONFIG.json)")
+	initCmd.Flags().StringVarP(&initConfig, "config", "c", "", "Path to config file (default: RALPH_ML_CONFIG.<format>)")
+	initCmd.Flags().StringVar(&initFormat, "format", "json", "Config file format: json, toml, or yaml")
+
+	initCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"json", "toml", "yaml"}, cobra.ShellCompDirectiveNoFileComp
+	})
 }
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/config"
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/display"
+)
+
+// JSONRequest is the non-interactive payload accepted by `--json` on `start`
+// and `resume`, for programmatic callers (schedulers, notebooks) that find
+// the mix of positional prompt arg and flags hard to drive.
+type JSONRequest struct {
+	Prompt         string            `json:"prompt"`
+	ConfigPath     string            `json:"config_path,omitempty"`
+	ConfigOverride *config.Config    `json:"config_override,omitempty"`
+	Plugin         string            `json:"plugin,omitempty"`
+	StatePath      string            `json:"state_path,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+}
+
+// readJSONRequest reads and parses a JSONRequest from pathOrDash, where "-"
+// means read from stdin.
+func readJSONRequest(pathOrDash string) (*JSONRequest, error) {
+	var data []byte
+	var err error
+
+	if pathOrDash == "-" {
+		data, err = io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JSON request from stdin: %w", err)
+		}
+	} else {
+		data, err = os.ReadFile(pathOrDash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JSON request file: %w", err)
+		}
+	}
+
+	var req JSONRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON request: %w", err)
+	}
+	if req.Prompt == "" {
+		return nil, fmt.Errorf("JSON request missing required field: prompt")
+	}
+
+	return &req, nil
+}
+
+// applyOutputFlag sets the display package's output mode from an --output
+// flag value ("human" or "json"), returning an error for anything else.
+func applyOutputFlag(value string) error {
+	switch value {
+	case "", "human":
+		display.SetOutputMode(display.OutputHuman)
+	case "json":
+		display.SetOutputMode(display.OutputJSON)
+	default:
+		return fmt.Errorf("unsupported output format: %s (expected human or json)", value)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/display"
+)
+
+func TestReadJSONRequestFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "request.json")
+	data := `{"prompt":"build a classifier","plugin":"claude","labels":{"team":"ml-platform"}}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("failed to write request file: %v", err)
+	}
+
+	req, err := readJSONRequest(path)
+	if err != nil {
+		t.Fatalf("readJSONRequest failed: %v", err)
+	}
+	if req.Prompt != "build a classifier" {
+		t.Errorf("Expected prompt 'build a classifier', got %q", req.Prompt)
+	}
+	if req.Plugin != "claude" {
+		t.Errorf("Expected plugin 'claude', got %q", req.Plugin)
+	}
+	if req.Labels["team"] != "ml-platform" {
+		t.Errorf("Expected label team=ml-platform, got %v", req.Labels)
+	}
+}
+
+func TestReadJSONRequestMissingPrompt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "request.json")
+	if err := os.WriteFile(path, []byte(`{"plugin":"claude"}`), 0644); err != nil {
+		t.Fatalf("failed to write request file: %v", err)
+	}
+
+	if _, err := readJSONRequest(path); err == nil {
+		t.Error("Expected an error for a request missing prompt")
+	}
+}
+
+func TestReadJSONRequestMissingFile(t *testing.T) {
+	if _, err := readJSONRequest(filepath.Join(t.TempDir(), "does-not-exist.json")); err == nil {
+		t.Error("Expected an error for a missing request file")
+	}
+}
+
+func TestApplyOutputFlag(t *testing.T) {
+	defer display.SetOutputMode(display.OutputHuman)
+
+	if err := applyOutputFlag("json"); err != nil {
+		t.Fatalf("applyOutputFlag(\"json\") failed: %v", err)
+	}
+	if err := applyOutputFlag(""); err != nil {
+		t.Fatalf("applyOutputFlag(\"\") failed: %v", err)
+	}
+	if err := applyOutputFlag("human"); err != nil {
+		t.Fatalf("applyOutputFlag(\"human\") failed: %v", err)
+	}
+	if err := applyOutputFlag("xml"); err == nil {
+		t.Error("Expected an error for an unsupported output format")
+	}
+}
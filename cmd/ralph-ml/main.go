@@ -23,6 +23,11 @@ func init() {
 	rootCmd.AddCommand(resumeCmd)
 	rootCmd.AddCommand(statusCmd)
 	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(pluginCmd)
+	rootCmd.AddCommand(completionCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(metricsCmd)
+	rootCmd.AddCommand(tailCmd)
 }
 
 func main() {
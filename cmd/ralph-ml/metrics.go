@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/display"
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+)
+
+var (
+	metricsServeAddr string
+	metricsServeDir  string
+)
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Prometheus metrics utilities",
+	Long:  `Serve aggregated Prometheus metrics collected from one or more Ralph ML Loop runs.`,
+}
+
+var metricsServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve /metrics aggregated across concurrent runs",
+	Long: `Serve a Prometheus /metrics endpoint that aggregates the snapshot files
+every 'ralph-ml start' invocation writes to PROMETHEUS_MULTIPROC_DIR,
+so a single sidecar process can expose a combined view even though each run
+is a separate OS process with its own in-memory registry.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := metricsServeDir
+		if dir == "" {
+			dir = os.Getenv(metrics.MultiprocDirEnv)
+		}
+		if dir == "" {
+			return fmt.Errorf("no multiprocess metrics directory set (pass --dir or set %s)", metrics.MultiprocDirEnv)
+		}
+
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(metrics.NewMultiprocCollector(dir))
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+		server := &http.Server{Addr: metricsServeAddr, Handler: mux}
+
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigCh)
+		go func() {
+			if _, ok := <-sigCh; ok {
+				_ = server.Shutdown(context.Background())
+			}
+		}()
+
+		display.Info(fmt.Sprintf("Serving aggregated Prometheus metrics on %s/metrics (reading %s)", metricsServeAddr, dir))
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			return fmt.Errorf("metrics server failed: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	metricsServeCmd.Flags().StringVar(&metricsServeAddr, "addr", ":9090", "Address to serve /metrics on")
+	metricsServeCmd.Flags().StringVar(&metricsServeDir, "dir", "", "Multiprocess metrics directory (defaults to "+metrics.MultiprocDirEnv+")")
+
+	metricsCmd.AddCommand(metricsServeCmd)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/agents"
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/display"
+	"github.com/spf13/cobra"
+)
+
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Manage third-party agent backend plugins",
+	Long:  `Install, list, and remove third-party agent backend plugins (Claude, Aider, custom scripts).`,
+}
+
+var pluginInstallCmd = &cobra.Command{
+	Use:   "install <path>",
+	Short: "Install a plugin from a local directory",
+	Long:  `Install an agent plugin by copying its manifest and entrypoint into ~/.ralph-ml/plugins.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manifest, err := agents.Install(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to install plugin: %w", err)
+		}
+
+		display.Success(fmt.Sprintf("Installed plugin %s@%s", manifest.Name, manifest.Version))
+		cmd.Printf("Use it by setting agents.code_model or agents.analysis_model to %q.\n", manifest.Name)
+		return nil
+	},
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List installed plugins",
+	Long:  `List agent plugins discovered from ~/.ralph-ml/plugins and ./plugins.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		registry, err := agents.NewRegistry()
+		if err != nil {
+			return fmt.Errorf("failed to load plugins: %w", err)
+		}
+
+		plugins := registry.List()
+		if len(plugins) == 0 {
+			cmd.Println("No plugins installed.")
+			return nil
+		}
+
+		for _, p := range plugins {
+			cmd.Printf("%-20s %-10s %s\n", p.Manifest.Name, p.Manifest.Version, p.Dir)
+		}
+		return nil
+	},
+}
+
+var pluginRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove an installed plugin",
+	Long:  `Remove a plugin previously installed with 'plugin install' from ~/.ralph-ml/plugins.`,
+	Args:  cobra.ExactArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		registry, err := agents.NewRegistry()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var names []string
+		for _, p := range registry.List() {
+			names = append(names, p.Manifest.Name)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := agents.Remove(args[0]); err != nil {
+			return fmt.Errorf("failed to remove plugin: %w", err)
+		}
+
+		display.Success(fmt.Sprintf("Removed plugin %s", args[0]))
+		return nil
+	},
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginInstallCmd)
+	pluginCmd.AddCommand(pluginListCmd)
+	pluginCmd.AddCommand(pluginRemoveCmd)
+}
@@ -1,21 +1,22 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
-	"time"
 
 	"github.com/matthiaspetry/DeepLoop/cli/pkg/display"
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/reports"
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/state"
 	"github.com/spf13/cobra"
 )
 
 var (
-	reportRun string
-	reportOut string
+	reportRun     string
+	reportOut     string
+	reportFormats []string
 )
 
 var reportCmd = &cobra.Command{
@@ -60,23 +61,36 @@ var reportCmd = &cobra.Command{
 			return fmt.Errorf("no cycles found")
 		}
 
-		// Generate report
-		report, err := generateReport(cycleDirs)
-		if err != nil {
-			return fmt.Errorf("failed to generate report: %w", err)
-		}
+		// Load cycle data once, shared by every renderer
+		cycles := reports.LoadCycles(cycleDirs)
 
 		// Create output directory
-		if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		outBase := strings.TrimSuffix(outputPath, filepath.Ext(outputPath))
+		if err := os.MkdirAll(filepath.Dir(outBase), 0755); err != nil {
 			return fmt.Errorf("failed to create reports directory: %w", err)
 		}
 
-		// Write report
-		if err := os.WriteFile(outputPath, []byte(report), 0644); err != nil {
-			return fmt.Errorf("failed to write report: %w", err)
+		for _, format := range reportFormats {
+			renderer, err := reports.RendererFor(format)
+			if err != nil {
+				return err
+			}
+
+			renderedPath := fmt.Sprintf("%s.%s", outBase, reports.Ext(format))
+			f, err := os.Create(renderedPath)
+			if err != nil {
+				return fmt.Errorf("failed to create report file: %w", err)
+			}
+
+			renderErr := renderer.Render(cycles, f)
+			f.Close()
+			if renderErr != nil {
+				return fmt.Errorf("failed to render %s report: %w", format, renderErr)
+			}
+
+			display.Success(fmt.Sprintf("Report generated: %s", renderedPath))
 		}
 
-		display.Success(fmt.Sprintf("Report generated: %s", outputPath))
 		cmd.Printf("Total cycles: %d\n", len(cycleDirs))
 
 		return nil
@@ -86,6 +100,11 @@ var reportCmd = &cobra.Command{
 func init() {
 	reportCmd.Flags().StringVarP(&reportRun, "run", "r", "./runs", "Path to runs directory")
 	reportCmd.Flags().StringVarP(&reportOut, "out", "o", "./reports/final_report.md", "Output report file")
+	reportCmd.Flags().StringArrayVar(&reportFormats, "format", []string{"markdown"}, "Report format(s) to generate: markdown, html, json, junit (repeatable)")
+
+	reportCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"markdown", "html", "json", "junit"}, cobra.ShellCompDirectiveNoFileComp
+	})
 }
 
 // resolveCyclesPath resolves the cycles directory path.
@@ -98,7 +117,7 @@ func resolveCyclesPath(runsPath string, cmd *cobra.Command) (string, bool, error
 
 		for _, entry := range entries {
 			if entry.IsDir() {
-				if len(entry.Name()) >= 6 && entry.Name()[:6] == "cycle_" {
+				if state.IsCycleDirName(entry.Name()) {
 					hasCycles = true
 					break
 				}
@@ -135,7 +154,9 @@ func resolveCyclesPath(runsPath string, cmd *cobra.Command) (string, bool, error
 	return runsPath, false, nil
 }
 
-// findCycleDirs finds all cycle directories.
+// findCycleDirs finds all finalized cycle directories, skipping any leftover
+// cycle_NNNN.tmp directory a crashed run left behind (see pkg/paths'
+// ResumeRun) since that doesn't match state.IsCycleDirName.
 func findCycleDirs(cyclesPath string) ([]string, error) {
 	var cycleDirs []string
 
@@ -145,7 +166,7 @@ func findCycleDirs(cyclesPath string) ([]string, error) {
 	}
 
 	for _, entry := range entries {
-		if entry.IsDir() && len(entry.Name()) >= 6 && entry.Name()[:6] == "cycle_" {
+		if entry.IsDir() && state.IsCycleDirName(entry.Name()) {
 			cycleDirs = append(cycleDirs, filepath.Join(cyclesPath, entry.Name()))
 		}
 	}
@@ -159,71 +180,3 @@ func findCycleDirs(cyclesPath string) ([]string, error) {
 
 	return cycleDirs, nil
 }
-
-// generateReport generates a markdown report from cycle directories.
-func generateReport(cycleDirs []string) (string, error) {
-	lines := []string{
-		"# Ralph ML Loop - Final Report",
-		"",
-		fmt.Sprintf("**Generated:** %s", time.Now().Format("2006-01-02 15:04:05")),
-		fmt.Sprintf("**Total cycles:** %d", len(cycleDirs)),
-		"",
-		"## Cycle Results",
-		"",
-	}
-
-	for _, cycleDir := range cycleDirs {
-		cycleName := filepath.Base(cycleDir)
-		lines = append(lines, fmt.Sprintf("### %s", cycleName), "")
-
-		// Read metrics
-		metricsPath := filepath.Join(cycleDir, "metrics.json")
-		if _, err := os.Stat(metricsPath); err == nil {
-			data, err := os.ReadFile(metricsPath)
-			if err == nil {
-				var metrics map[string]interface{}
-				if json.Unmarshal(data, &metrics) == nil {
-					lines = append(lines, "**Metrics:**")
-					if cycleNum, ok := metrics["cycle"].(float64); ok {
-						lines = append(lines, fmt.Sprintf("- Cycle: %.0f", cycleNum))
-					}
-					if result, ok := metrics["result"].(map[string]interface{}); ok {
-						lines = append(lines, "- Results:")
-						for key, value := range result {
-							lines = append(lines, fmt.Sprintf("  - %s: %v", key, value))
-						}
-					}
-				}
-			}
-		}
-
-		// Read analysis
-		analysisPath := filepath.Join(cycleDir, "analysis.json")
-		if _, err := os.Stat(analysisPath); err == nil {
-			data, err := os.ReadFile(analysisPath)
-			if err == nil {
-				var analysis map[string]interface{}
-				if json.Unmarshal(data, &analysis) == nil {
-					if summary, ok := analysis["summary"].(string); ok {
-						lines = append(lines, "")
-						lines = append(lines, "**Summary:**")
-						lines = append(lines, summary)
-					}
-					if decision, ok := analysis["decision"].(map[string]interface{}); ok {
-						lines = append(lines, "")
-						if action, ok := decision["action"].(string); ok {
-							lines = append(lines, fmt.Sprintf("**Decision:** %s", action))
-						}
-						if rationale, ok := decision["rationale"].(string); ok {
-							lines = append(lines, fmt.Sprintf("_%s_", rationale))
-						}
-					}
-				}
-			}
-		}
-
-		lines = append(lines, "")
-	}
-
-	return strings.Join(lines, "\n"), nil
-}
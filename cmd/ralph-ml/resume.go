@@ -1,9 +1,19 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/agents"
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/config"
 	"github.com/matthiaspetry/DeepLoop/cli/pkg/display"
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/orchestrator"
 	"github.com/matthiaspetry/DeepLoop/cli/pkg/state"
 	"github.com/spf13/cobra"
 )
@@ -11,54 +21,180 @@ import (
 var (
 	resumeState  string
 	resumePrompt string
+	resumeConfig string
+	resumeJSON   string
+	resumeOutput string
 )
 
 var resumeCmd = &cobra.Command{
 	Use:   "resume",
 	Short: "Resume a previous Ralph ML Loop run",
-	Long:  `Resume a previous Ralph ML Loop run from a saved state file.`,
+	Long:  `Resume a previous Ralph ML Loop run from a saved state file, continuing at the next cycle instead of redoing completed ones.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Load state
+		if err := applyOutputFlag(resumeOutput); err != nil {
+			return err
+		}
+
+		if resumeJSON != "" {
+			jsonReq, err := readJSONRequest(resumeJSON)
+			if err != nil {
+				return err
+			}
+			resumePrompt = jsonReq.Prompt
+			if jsonReq.StatePath != "" {
+				resumeState = jsonReq.StatePath
+			}
+			if jsonReq.ConfigPath != "" {
+				resumeConfig = jsonReq.ConfigPath
+			}
+		}
+
+		// Load state, letting RALPH_ML_STATE_DIR override the --state flag
+		// so resume can find state relocated for CI/container use. Only a
+		// plain path is affected; a URL-style --state (sqlite://, s3://,
+		// http(s)://) already names its backend explicitly.
 		statePath := resumeState
-		if statePath == "" {
-			statePath = "./state/ralph_state.json"
+		if v := os.Getenv(config.EnvStateDir); v != "" && !strings.Contains(statePath, "://") {
+			statePath = filepath.Join(v, "ralph_state.json")
 		}
 
-		stateFile, err := state.LoadState(statePath)
+		provider, err := state.NewProvider(statePath)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		stateFile, err := provider.Load(ctx)
 		if err != nil {
 			display.Error(fmt.Sprintf("Failed to load state: %v", err))
 			return fmt.Errorf("failed to load state: %w", err)
 		}
 
-		cmd.Printf("🔄 Resuming from cycle %d\n", stateFile.CurrentCycle)
+		display.Info(fmt.Sprintf("Resuming from cycle %d (%d cycles recorded)", stateFile.CurrentCycle, len(stateFile.History)))
 		if stateFile.BestMetric != nil {
-			cmd.Printf("Best metric so far: %.4f\n", *stateFile.BestMetric)
+			display.Info(fmt.Sprintf("Best metric so far: %.4f (cycle %d)", *stateFile.BestMetric, stateFile.BestCycle))
 		} else {
-			cmd.Println("Best metric so far: N/A")
+			display.Info("Best metric so far: N/A")
 		}
 
-		// Check if prompt is provided
-		if resumePrompt == "" {
-			cmd.Println("\n⚠️  Resume functionality requires the original prompt.")
-			cmd.Println("Please use the original prompt you used when starting the run.")
+		// Resolve the prompt to resume with: prefer the prompt persisted by
+		// `start`, and treat an explicit --prompt as an override that must
+		// match the original, so we never silently resume against a
+		// different prompt than the one the history was built from.
+		storedPrompt, storedHash, havePrompt := stateFile.StoredPrompt()
+		switch {
+		case resumePrompt == "" && havePrompt:
+			resumePrompt = storedPrompt
+		case resumePrompt == "" && !havePrompt:
+			display.Warning("Resume functionality requires the original prompt.")
+			cmd.Println("This state file doesn't have a stored prompt (it predates this feature).")
+			cmd.Println("Please pass the original prompt explicitly.")
 			cmd.Println("Example: ralph-ml resume --prompt \"your original prompt\" --state ./state/ralph_state.json")
-			return nil
+			return fmt.Errorf("no prompt available to resume with")
+		case resumePrompt != "" && havePrompt && state.HashPrompt(resumePrompt) != storedHash:
+			display.Error("The --prompt you passed does not match the prompt this run was started with.")
+			return fmt.Errorf("prompt hash mismatch on resume")
+		}
+
+		display.Info(fmt.Sprintf("Resuming with prompt: %s", resumePrompt))
+
+		cfg, err := loadConfigForResume()
+		if err != nil {
+			return err
+		}
+		config.ApplyEnvOverrides(cfg)
+
+		pythonPath, err := orchestrator.DetectPythonPath(cfg.Execution.Python)
+		if err != nil {
+			display.PrintPythonNotFound()
+			return fmt.Errorf("python detection failed: %w", err)
+		}
+		cfg.Execution.Python = pythonPath
+
+		orch := orchestrator.NewOrchestrator()
+		orch.SetPythonPath(pythonPath)
+		orch.SetResumeFromCycle(stateFile.CurrentCycle + 1)
+
+		if registry, err := agents.NewRegistry(); err != nil {
+			display.Warning(fmt.Sprintf("Failed to load agent plugins: %v", err))
+		} else {
+			orch.SetAgent(registry, cfg.Agents.CodeModel)
 		}
 
-		// Resume with the provided prompt
-		cmd.Printf("\n🚀 Resuming with prompt: %s\n", resumePrompt)
+		timeoutMinutes := cfg.Safeguards.TimeLimitPerCycleMinutes
+		if timeoutMinutes <= 0 {
+			timeoutMinutes = 30
+		}
+
+		runCtx, cancel := context.WithTimeout(ctx, time.Duration(timeoutMinutes)*time.Minute)
+		defer cancel()
+
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigCh)
+		go func() {
+			if _, ok := <-sigCh; ok {
+				display.Warning("Shutting down, waiting for the orchestrator to exit gracefully...")
+				cancel()
+			}
+		}()
 
-		// Note: For full resume functionality, we'd need to implement state-aware orchestrator
-		// This is a simplified version that restarts the loop
-		cmd.Println("\n⚠️  Full resume functionality coming soon.")
-		cmd.Println("Currently, you can use 'start' with the same prompt to continue manually.")
-		cmd.Println("The state file contains your history and best metrics for reference.")
+		display.Progress(fmt.Sprintf("Resuming Ralph ML Loop at cycle %d...", stateFile.CurrentCycle+1))
+
+		orchestratorOutput := display.NewEventWriter("orchestrator_output", os.Stdout)
+		if err := orch.RunWithStreaming(runCtx, resumePrompt, resumeConfig, orchestratorOutput); err != nil {
+			display.Error(fmt.Sprintf("Orchestrator failed: %v", err))
+			return err
+		}
 
+		display.Success("Ralph ML Loop completed!")
 		return nil
 	},
 }
 
 func init() {
-	resumeCmd.Flags().StringVarP(&resumeState, "state", "s", "./state/ralph_state.json", "Path to state file")
-	resumeCmd.Flags().StringVarP(&resumePrompt, "prompt", "p", "", "Original prompt used to start the run (optional)")
+	resumeCmd.Flags().StringVarP(&resumeState, "state", "s", "./state/ralph_state.json", "State backend URL (file://, sqlite://, s3://, http(s)://) or a plain path")
+	resumeCmd.Flags().StringVarP(&resumePrompt, "prompt", "p", "", "Original prompt used to start the run (defaults to the prompt stored in state)")
+	resumeCmd.Flags().StringVarP(&resumeConfig, "config", "c", "", "Path to config file")
+	resumeCmd.Flags().StringVar(&resumeJSON, "json", "", "Path to a JSON request payload (prompt, state_path), or '-' for stdin")
+	resumeCmd.Flags().StringVar(&resumeOutput, "output", "human", "Output format: human or json")
+
+	resumeCmd.RegisterFlagCompletionFunc("state", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		entries, err := os.ReadDir("./runs")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var completions []string
+		for _, entry := range entries {
+			if entry.IsDir() && len(entry.Name()) >= 4 && entry.Name()[:4] == "run_" {
+				completions = append(completions, filepath.Join("./runs", entry.Name(), "state", "ralph_state.json"))
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// loadConfigForResume loads configuration for the resume command, matching
+// loadConfigForStart's resolution order without requiring a run directory.
+func loadConfigForResume() (*config.Config, error) {
+	var cfg *config.Config
+
+	if resumeConfig != "" {
+		var err error
+		cfg, err = config.LoadConfig(resumeConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config: %w", err)
+		}
+	} else if _, err := os.Stat("RALPH_ML_CONFIG.json"); err == nil {
+		cfg, err = config.LoadConfig("RALPH_ML_CONFIG.json")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load default config: %w", err)
+		}
+	} else {
+		cfg = config.NewDefaultConfig()
+	}
+
+	config.ApplyEnvOverrides(cfg)
+	return cfg, nil
 }
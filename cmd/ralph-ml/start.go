@@ -4,44 +4,89 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
 	"time"
 
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/agents"
 	"github.com/matthiaspetry/DeepLoop/cli/pkg/config"
 	"github.com/matthiaspetry/DeepLoop/cli/pkg/display"
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/metrics"
 	"github.com/matthiaspetry/DeepLoop/cli/pkg/orchestrator"
 	"github.com/matthiaspetry/DeepLoop/cli/pkg/paths"
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/pyenv"
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/state"
 	"github.com/spf13/cobra"
 )
 
 var (
-	startConfig    string
-	startNoConfig  bool
-	startTarget    float64
-	startMaxCycles int
-	startDataRoot  string
-	startFramework string
-	startPython   string
+	startConfig       string
+	startNoConfig     bool
+	startTarget       float64
+	startMaxCycles    int
+	startDataRoot     string
+	startFramework    string
+	startPython       string
+	startMetricsAddr  string
+	startJSON         string
+	startOutput       string
+	startResume       string
+	startSkipEnvCheck bool
+	startState        string
 )
 
 var startCmd = &cobra.Command{
 	Use:   "start [prompt]",
 	Short: "Start the Ralph ML Loop",
 	Long:  `Start the Ralph ML Loop with a prompt describing the model to build.`,
-	Args:  cobra.ExactArgs(1),
+	Args: func(cmd *cobra.Command, args []string) error {
+		if startJSON != "" {
+			return cobra.MaximumNArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		prompt := args[0]
+		if err := applyOutputFlag(startOutput); err != nil {
+			return err
+		}
+
+		var prompt string
+		var jsonReq *JSONRequest
+		var pluginOverride string
+		if startJSON != "" {
+			var err error
+			jsonReq, err = readJSONRequest(startJSON)
+			if err != nil {
+				return err
+			}
+			prompt = jsonReq.Prompt
+			if jsonReq.ConfigPath != "" {
+				startConfig = jsonReq.ConfigPath
+			}
+			pluginOverride = jsonReq.Plugin
+		} else {
+			prompt = args[0]
+		}
 
 		// Load config
 		cfg, configPath, err := loadConfigForStart()
 		if err != nil {
 			return err
 		}
+		if jsonReq != nil && jsonReq.ConfigOverride != nil {
+			cfg = jsonReq.ConfigOverride
+		}
 
-		cmd.Printf("📄 Using config: %s\n", configPath)
+		display.Info(fmt.Sprintf("Using config: %s", configPath))
 
-		// Apply CLI overrides
+		// Apply CLI overrides, then env overrides (env wins over everything)
 		applyConfigOverrides(cfg)
+		config.ApplyEnvOverrides(cfg)
+		if pluginOverride != "" {
+			cfg.Agents.CodeModel = pluginOverride
+		}
 
 		// Resolve data root to absolute path
 		absDataRoot, err := filepath.Abs(cfg.Data.Root)
@@ -64,13 +109,29 @@ var startCmd = &cobra.Command{
 			return fmt.Errorf("failed to resolve paths: %w", err)
 		}
 
-		runRoot, err := p.CreateRunDirectory()
-		if err != nil {
-			return fmt.Errorf("failed to create run directory: %w", err)
+		var runRoot string
+		var resumeFromCycle int
+		if startResume != "" {
+			var resumed *paths.Paths
+			if startResume == "latest" {
+				resumed, resumeFromCycle, err = p.ResumeLatest()
+			} else {
+				resumed, resumeFromCycle, err = p.ResumeRun(startResume)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to resume run: %w", err)
+			}
+			p = resumed
+			runRoot = p.RunRoot
+			display.Info(fmt.Sprintf("Resuming run directory: %s (cycle %d)", runRoot, resumeFromCycle))
+		} else {
+			runRoot, err = p.CreateRunDirectory()
+			if err != nil {
+				return fmt.Errorf("failed to create run directory: %w", err)
+			}
+			display.Info(fmt.Sprintf("Run directory: %s", runRoot))
 		}
 
-		cmd.Printf("📁 Run directory: %s\n", runRoot)
-
 		// Show platform info (helpful for debugging)
 		if paths.IsWindows() {
 			display.Info(fmt.Sprintf("Using Python: %s", pythonPath))
@@ -83,9 +144,90 @@ var startCmd = &cobra.Command{
 			return fmt.Errorf("failed to save resolved config: %w", err)
 		}
 
+		// Persist the prompt (and its hash) into the run's state so
+		// `resume --state` alone is enough to continue it later, and a
+		// --prompt passed to resume is checked against what we started with.
+		// --state picks the backend (file://, sqlite://, s3://, http(s)://);
+		// it defaults to the run's own state file on local disk.
+		statePath := startState
+		if statePath == "" {
+			statePath = p.GetStatePath()
+		}
+		stateProvider, err := state.NewProvider(statePath)
+		if err != nil {
+			return err
+		}
+
+		// collectors tracks run metrics regardless of whether the embedded
+		// Prometheus HTTP server is enabled below, so a multiprocess snapshot
+		// is always available to a sidecar `ralph-ml metrics serve`.
+		collectors := metrics.NewCollectors()
+		if multiprocDir := os.Getenv(metrics.MultiprocDirEnv); multiprocDir != "" {
+			defer func() {
+				_ = collectors.WriteMultiprocSnapshot(multiprocDir)
+			}()
+		}
+		collectors.SetCurrentCycle(resumeFromCycle)
+
+		var st *state.State
+		if resumeFromCycle > 0 {
+			st, err = stateProvider.Load(context.Background())
+			if err != nil {
+				return fmt.Errorf("failed to load existing state for resume: %w", err)
+			}
+			if storedPrompt, storedHash, ok := st.StoredPrompt(); ok && state.HashPrompt(prompt) != storedHash {
+				display.Error(fmt.Sprintf("Prompt does not match the one this run was started with: %q", storedPrompt))
+				return fmt.Errorf("prompt hash mismatch on resume")
+			}
+			if st.BestMetric != nil {
+				collectors.SetBestMetric(*st.BestMetric)
+			}
+		} else {
+			st = &state.State{Status: "running"}
+		}
+		st.Config = state.WithPrompt(st.Config, prompt)
+		if jsonReq != nil {
+			st.Config = state.WithLabels(st.Config, jsonReq.Labels)
+		}
+		if err := stateProvider.Save(context.Background(), st); err != nil {
+			collectors.IncStateSaveErrors()
+			return fmt.Errorf("failed to save state: %w", err)
+		}
+
 		// Set up orchestrator
 		orch := orchestrator.NewOrchestrator()
 		orch.SetPythonPath(cfg.Execution.Python)
+		if resumeFromCycle > 0 {
+			orch.SetResumeFromCycle(resumeFromCycle)
+		}
+
+		if registry, err := agents.NewRegistry(); err != nil {
+			display.Warning(fmt.Sprintf("Failed to load agent plugins: %v", err))
+		} else {
+			orch.SetAgent(registry, cfg.Agents.CodeModel)
+		}
+
+		// Start Prometheus metrics server, if enabled
+		if startMetricsAddr != "" {
+			cfg.Observability.Prometheus.Enable = true
+			cfg.Observability.Prometheus.ListenAddr = startMetricsAddr
+		}
+
+		var metricsServer *metrics.Server
+		if cfg.Observability.Prometheus.Enable {
+			metricsServer, err = metrics.NewServer(collectors, cfg.Observability.Prometheus.ListenAddr, cfg.Observability.Prometheus.Path)
+			if err != nil {
+				return fmt.Errorf("failed to start metrics server: %w", err)
+			}
+			if cfg.Observability.Prometheus.PushGatewayURL != "" {
+				metricsServer.EnablePush(cfg.Observability.Prometheus.PushGatewayURL, cfg.Project.Name)
+			}
+			display.Info(fmt.Sprintf("Serving Prometheus metrics on %s%s", cfg.Observability.Prometheus.ListenAddr, cfg.Observability.Prometheus.Path))
+			defer func() {
+				_ = metricsServer.PushFinal()
+				_ = metricsServer.Shutdown(context.Background())
+			}()
+		}
 
 		// Check Python availability
 		pythonVer, err := orch.CheckPython()
@@ -94,7 +236,29 @@ var startCmd = &cobra.Command{
 			display.PrintPythonNotFound()
 			return fmt.Errorf("python check failed: %w", err)
 		}
-		cmd.Printf("🐍 Python: %s\n", pythonVer)
+		display.Info(fmt.Sprintf("Python: %s", pythonVer))
+
+		// Verify the environment has the minimum Python version and every
+		// required package importable, so crashes show up here instead of
+		// as an opaque [STDERR] failure partway through a cycle.
+		if !startSkipEnvCheck {
+			envResult, err := pyenv.CheckEnvironment(cfg.Execution.Python, pyenv.RequiredModules(cfg.Project.Framework))
+			if err != nil {
+				display.Error(fmt.Sprintf("Environment check failed: %v", err))
+				return fmt.Errorf("environment check failed: %w", err)
+			}
+			if !envResult.OK() {
+				if !envResult.MeetsMinVersion {
+					display.Error(fmt.Sprintf("Python %d.%d or newer is required", pyenv.MinPythonVersion[0], pyenv.MinPythonVersion[1]))
+				}
+				if len(envResult.MissingModules) > 0 {
+					display.Error(fmt.Sprintf("Missing packages: %s", strings.Join(envResult.MissingModules, ", ")))
+					cmd.Printf("Install them with: %s -m pip install %s\n", cfg.Execution.Python, strings.Join(envResult.MissingModules, " "))
+				}
+				cmd.Println("Run 'ralph-ml doctor' for details, or pass --skip-env-check to bypass this check.")
+				return fmt.Errorf("environment check failed")
+			}
+		}
 
 		// Run orchestrator with timeout
 		timeoutMinutes := cfg.Safeguards.TimeLimitPerCycleMinutes
@@ -105,15 +269,44 @@ var startCmd = &cobra.Command{
 		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutMinutes)*time.Minute)
 		defer cancel()
 
+		// On Ctrl-C (or a TERM from a process manager), cancel the context and
+		// let orch.Run/RunWithStreaming escalate interrupt -> terminate -> kill
+		// against the orchestrator's process group, instead of leaving orphaned
+		// Python child processes behind.
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigCh)
+		go func() {
+			if _, ok := <-sigCh; ok {
+				display.Warning("Shutting down, waiting for the orchestrator to exit gracefully...")
+				cancel()
+			}
+		}()
+
 		display.Progress("Starting Ralph ML Loop...")
-		fmt.Println()
 
-		// Run orchestrator with streaming
-		if err := orch.RunWithStreaming(ctx, prompt, configPath, os.Stdout); err != nil {
+		collectors.CyclesStarted.Inc()
+		collectors.IncCyclesTotal()
+		stopTimer := collectors.TimeCycle()
+		defer stopTimer()
+
+		// Run orchestrator with streaming, wrapping each line as a JSON event
+		// when --output json is set.
+		orchestratorOutput := display.NewEventWriter("orchestrator_output", os.Stdout)
+		if err := orch.RunWithStreaming(ctx, prompt, configPath, orchestratorOutput); err != nil {
 			display.Error(fmt.Sprintf("Orchestrator failed: %v", err))
 			return err
 		}
 
+		collectors.CyclesSucceeded.Inc()
+
+		if finalState, err := stateProvider.Load(context.Background()); err == nil {
+			collectors.SetCurrentCycle(finalState.CurrentCycle)
+			if finalState.BestMetric != nil {
+				collectors.SetBestMetric(*finalState.BestMetric)
+			}
+		}
+
 		display.Success("Ralph ML Loop completed!")
 		return nil
 	},
@@ -127,6 +320,39 @@ func init() {
 	startCmd.Flags().StringVar(&startDataRoot, "data-root", "", "Override dataset root path")
 	startCmd.Flags().StringVar(&startFramework, "framework", "", "Override framework (pytorch/tensorflow/jax)")
 	startCmd.Flags().StringVarP(&startPython, "python", "p", "", "Python interpreter path (auto-detected if not specified)")
+	startCmd.Flags().StringVar(&startMetricsAddr, "metrics-addr", "", "Serve Prometheus metrics on this address (overrides config, e.g. ':9090')")
+	startCmd.Flags().StringVar(&startJSON, "json", "", "Path to a JSON request payload (prompt, config overrides, plugin, labels), or '-' for stdin")
+	startCmd.Flags().StringVar(&startOutput, "output", "human", "Output format: human or json")
+	startCmd.Flags().StringVar(&startResume, "resume", "", "Resume an existing run directory instead of creating a new one (run ID, or \"latest\")")
+	startCmd.Flags().BoolVar(&startSkipEnvCheck, "skip-env-check", false, "Skip verifying the Python environment (version + required packages) before running")
+	startCmd.Flags().StringVar(&startState, "state", "", "State backend URL (file://, sqlite://, s3://, http(s)://); defaults to the run's own state file")
+
+	startCmd.RegisterFlagCompletionFunc("config", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"json"}, cobra.ShellCompDirectiveFilterFileExt
+	})
+
+	startCmd.RegisterFlagCompletionFunc("framework", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"pytorch", "tensorflow", "jax"}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	startCmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"human", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	startCmd.RegisterFlagCompletionFunc("resume", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		entries, err := os.ReadDir("./runs")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		completions := []string{"latest"}
+		for _, entry := range entries {
+			if entry.IsDir() && strings.HasPrefix(entry.Name(), "run_") {
+				completions = append(completions, entry.Name())
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	})
 }
 
 // loadConfigForStart loads configuration for the start command.
@@ -175,6 +401,8 @@ func loadConfigForStart() (*config.Config, string, error) {
 		}
 	}
 
+	config.ApplyEnvOverrides(cfg)
+
 	return cfg, configPath, nil
 }
 
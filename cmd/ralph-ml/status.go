@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/config"
 	"github.com/matthiaspetry/DeepLoop/cli/pkg/display"
 	"github.com/matthiaspetry/DeepLoop/cli/pkg/state"
 	"github.com/spf13/cobra"
@@ -16,8 +17,12 @@ var statusCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		display.PrintSection("📊 Ralph ML Loop Status")
 
-		// Scan runs directory
+		// Scan runs directory, letting RALPH_ML_RUNS_DIR override the default
+		// so status can see runs redirected for CI/container use.
 		runsDir := "./runs"
+		if v := os.Getenv(config.EnvRunsDir); v != "" {
+			runsDir = v
+		}
 		runs, stateFile, err := state.ScanRuns(runsDir)
 		if err != nil {
 			return fmt.Errorf("failed to scan runs: %w", err)
@@ -34,6 +39,11 @@ var statusCmd = &cobra.Command{
 			cmd.Println("No runs directory found.")
 		}
 
+		legacyStateDir := "./state"
+		if v := os.Getenv(config.EnvStateDir); v != "" {
+			legacyStateDir = v
+		}
+
 		// Show state
 		var statePath string
 		if stateFile != nil {
@@ -49,12 +59,12 @@ var statusCmd = &cobra.Command{
 			}
 			if statePath == "" {
 				// Legacy state file
-				statePath = "./state/ralph_state.json"
+				statePath = legacyStateDir + "/ralph_state.json"
 			}
 			display.PrintState(stateFile, statePath)
 		} else {
 			// Try legacy state file
-			legacyStatePath := "./state/ralph_state.json"
+			legacyStatePath := legacyStateDir + "/ralph_state.json"
 			if legacyState, err := state.LoadState(legacyStatePath); err == nil {
 				display.PrintState(legacyState, legacyStatePath)
 			}
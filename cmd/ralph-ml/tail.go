@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/display"
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/state"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tailState     string
+	tailMaxCycles int
+	tailTarget    float64
+)
+
+var tailCmd = &cobra.Command{
+	Use:   "tail",
+	Short: "Follow a run's state file with a live progress bar",
+	Long: `Watch a state file for new cycles, best-metric improvements, and status
+changes, redrawing a single-line progress bar as they happen instead of
+requiring the caller to poll 'status' themselves.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if st, err := state.LoadState(tailState); err == nil {
+			renderTailState(st, tailMaxCycles, tailTarget)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to load state: %w", err)
+		} else {
+			display.Warning(fmt.Sprintf("No state file at %s yet, waiting for it to appear...", tailState))
+		}
+
+		ch, stop, err := state.Watch(tailState)
+		if err != nil {
+			return fmt.Errorf("failed to watch state file: %w", err)
+		}
+
+		// On Ctrl-C (or a TERM from a process manager), stop the watch;
+		// stop() flushes any pending event to ch before closing it, and the
+		// range loop below drains that final event before exiting, so the
+		// last progress update is never lost.
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigCh)
+		go func() {
+			if _, ok := <-sigCh; ok {
+				stop()
+			}
+		}()
+
+		for ev := range ch {
+			renderTailState(ev.State, tailMaxCycles, tailTarget)
+			if ev.Type == state.StatusChanged && ev.State.Status != "running" {
+				// stop() blocks until Watch's goroutine flushes its final
+				// event onto ch, which this same loop is the only reader
+				// of; calling it inline here would deadlock, so run it in
+				// its own goroutine and let the range loop above keep
+				// draining until the channel closes.
+				go stop()
+			}
+		}
+
+		cmd.Println()
+		return nil
+	},
+}
+
+// renderTailState redraws the progress bar for st.
+func renderTailState(st *state.State, maxCycles int, target float64) {
+	display.PrintProgressBar(st.CurrentCycle, maxCycles, st.BestMetric, target)
+}
+
+func init() {
+	tailCmd.Flags().StringVarP(&tailState, "state", "s", "./state/ralph_state.json", "Path to the state file to watch")
+	tailCmd.Flags().IntVar(&tailMaxCycles, "max-cycles", 0, "Expected total cycles, for the progress bar (0 if unknown)")
+	tailCmd.Flags().Float64Var(&tailTarget, "target", 0, "Target metric value, for the progress bar")
+}
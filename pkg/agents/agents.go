@@ -0,0 +1,233 @@
+// Package agents implements the plugin subsystem for third-party agent
+// backends (Claude, Aider, custom scripts) that can be selected from
+// AgentsConfig.CodeModel / AnalysisModel instead of the built-in "opencode"
+// runner.
+package agents
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ManifestFileName is the name of the manifest file inside a plugin directory.
+const ManifestFileName = "plugin.json"
+
+// Manifest describes a single agent plugin, discovered from a JSON manifest
+// file shipped alongside the plugin's entrypoint.
+type Manifest struct {
+	Name           string   `json:"name"`
+	Version        string   `json:"version"`
+	Entrypoint     string   `json:"entrypoint"`
+	Args           []string `json:"args,omitempty"`
+	RequiredEnv    []string `json:"required_env,omitempty"`
+	SupportedTasks []string `json:"supported_tasks,omitempty"`
+}
+
+// Plugin is a resolved, on-disk plugin: its manifest plus the directory it
+// was loaded from (entrypoint paths are resolved relative to this).
+type Plugin struct {
+	Manifest Manifest
+	Dir      string
+}
+
+// Command returns the executable and arguments to launch this plugin.
+func (p Plugin) Command() (string, []string) {
+	entry := p.Manifest.Entrypoint
+	if !filepath.IsAbs(entry) {
+		entry = filepath.Join(p.Dir, entry)
+	}
+	return entry, p.Manifest.Args
+}
+
+// Env returns the environment to launch this plugin's subprocess with: the
+// current process environment, checked to confirm every variable the
+// manifest's required_env lists is actually set. It errors out naming the
+// missing ones instead of silently starting the plugin without them.
+func (p Plugin) Env() ([]string, error) {
+	env := os.Environ()
+	if len(p.Manifest.RequiredEnv) == 0 {
+		return env, nil
+	}
+
+	set := make(map[string]bool, len(env))
+	for _, kv := range env {
+		if name, _, ok := strings.Cut(kv, "="); ok {
+			set[name] = true
+		}
+	}
+
+	var missing []string
+	for _, name := range p.Manifest.RequiredEnv {
+		if !set[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("plugin %s requires environment variables that are not set: %s", p.Manifest.Name, strings.Join(missing, ", "))
+	}
+
+	return env, nil
+}
+
+// Registry resolves a `code_model`/`analysis_model` string to a Plugin.
+type Registry struct {
+	plugins map[string]Plugin
+}
+
+// UserPluginsDir returns the per-user plugin directory, ~/.ralph-ml/plugins.
+func UserPluginsDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".ralph-ml", "plugins"), nil
+}
+
+// ProjectPluginsDir returns the per-project plugin directory, ./plugins.
+func ProjectPluginsDir() string {
+	return "plugins"
+}
+
+// NewRegistry builds a Registry by discovering plugins from the per-user
+// directory first, then the per-project directory (which wins on name
+// collisions, so a project can pin a different version than the user's
+// global install).
+func NewRegistry() (*Registry, error) {
+	r := &Registry{plugins: map[string]Plugin{}}
+
+	if userDir, err := UserPluginsDir(); err == nil {
+		if err := r.discover(userDir); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	if err := r.discover(ProjectPluginsDir()); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// discover scans dir for immediate subdirectories containing a plugin.json
+// manifest and registers each one.
+func (r *Registry) discover(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		pluginDir := filepath.Join(dir, entry.Name())
+		manifest, err := LoadManifest(pluginDir)
+		if err != nil {
+			continue
+		}
+		r.plugins[manifest.Name] = Plugin{Manifest: *manifest, Dir: pluginDir}
+	}
+
+	return nil
+}
+
+// LoadManifest reads and parses the plugin.json manifest inside pluginDir.
+func LoadManifest(pluginDir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(pluginDir, ManifestFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse plugin manifest: %w", err)
+	}
+	if manifest.Name == "" {
+		return nil, fmt.Errorf("plugin manifest missing required field: name")
+	}
+	if manifest.Entrypoint == "" {
+		return nil, fmt.Errorf("plugin manifest missing required field: entrypoint")
+	}
+
+	return &manifest, nil
+}
+
+// Resolve looks up a plugin by the `code_model`/`analysis_model` name used in
+// AgentsConfig. It returns false if no plugin is registered under that name,
+// which callers should treat as "use the built-in runner".
+func (r *Registry) Resolve(name string) (Plugin, bool) {
+	p, ok := r.plugins[name]
+	return p, ok
+}
+
+// List returns all registered plugins, in no particular order.
+func (r *Registry) List() []Plugin {
+	plugins := make([]Plugin, 0, len(r.plugins))
+	for _, p := range r.plugins {
+		plugins = append(plugins, p)
+	}
+	return plugins
+}
+
+// Install copies a plugin from srcDir (a directory containing plugin.json)
+// into the per-user plugins directory under its manifest name. Fetching from
+// a URL isn't wired up yet, so one is rejected with a clear error instead of
+// being treated as (and failing as) a local path.
+func Install(srcDir string) (*Manifest, error) {
+	if strings.Contains(srcDir, "://") {
+		return nil, fmt.Errorf("installing a plugin from a URL is not supported yet: download it locally and pass the directory path instead")
+	}
+
+	manifest, err := LoadManifest(srcDir)
+	if err != nil {
+		return nil, err
+	}
+
+	userDir, err := UserPluginsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	destDir := filepath.Join(userDir, manifest.Name)
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create plugin directory: %w", err)
+	}
+
+	entries, err := os.ReadDir(srcDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin source: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(srcDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read plugin file %s: %w", entry.Name(), err)
+		}
+		if err := os.WriteFile(filepath.Join(destDir, entry.Name()), data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write plugin file %s: %w", entry.Name(), err)
+		}
+	}
+
+	return manifest, nil
+}
+
+// Remove deletes an installed plugin's directory from the per-user plugins
+// directory.
+func Remove(name string) error {
+	userDir, err := UserPluginsDir()
+	if err != nil {
+		return err
+	}
+
+	pluginDir := filepath.Join(userDir, name)
+	if _, err := os.Stat(pluginDir); os.IsNotExist(err) {
+		return fmt.Errorf("plugin not installed: %s", name)
+	}
+
+	return os.RemoveAll(pluginDir)
+}
@@ -0,0 +1,143 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeManifest(t *testing.T, dir string, m Manifest) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create plugin dir: %v", err)
+	}
+	data := `{"name":"` + m.Name + `","version":"` + m.Version + `","entrypoint":"` + m.Entrypoint + `"}`
+	if err := os.WriteFile(filepath.Join(dir, ManifestFileName), []byte(data), 0644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+}
+
+func TestLoadManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeManifest(t, tmpDir, Manifest{Name: "claude", Version: "1.0.0", Entrypoint: "run.sh"})
+
+	manifest, err := LoadManifest(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to load manifest: %v", err)
+	}
+
+	if manifest.Name != "claude" {
+		t.Errorf("Expected name 'claude', got '%s'", manifest.Name)
+	}
+	if manifest.Entrypoint != "run.sh" {
+		t.Errorf("Expected entrypoint 'run.sh', got '%s'", manifest.Entrypoint)
+	}
+}
+
+func TestLoadManifestMissingFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, ManifestFileName), []byte(`{"version":"1.0.0"}`), 0644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	if _, err := LoadManifest(tmpDir); err == nil {
+		t.Error("Expected error for manifest missing name/entrypoint")
+	}
+}
+
+func TestPluginCommand(t *testing.T) {
+	p := Plugin{
+		Manifest: Manifest{Entrypoint: "run.sh", Args: []string{"--flag"}},
+		Dir:      "/plugins/claude",
+	}
+
+	exe, args := p.Command()
+	if exe != "/plugins/claude/run.sh" {
+		t.Errorf("Expected resolved entrypoint, got '%s'", exe)
+	}
+	if len(args) != 1 || args[0] != "--flag" {
+		t.Errorf("Expected args ['--flag'], got %v", args)
+	}
+}
+
+func TestPluginEnvPassesThroughWithoutRequiredEnv(t *testing.T) {
+	p := Plugin{Manifest: Manifest{Entrypoint: "run.sh"}}
+
+	env, err := p.Env()
+	if err != nil {
+		t.Fatalf("Env failed: %v", err)
+	}
+	if len(env) == 0 {
+		t.Error("Expected the process environment to be passed through")
+	}
+}
+
+func TestPluginEnvErrorsOnMissingRequiredEnv(t *testing.T) {
+	t.Setenv("RALPH_ML_TEST_PRESENT", "1")
+	p := Plugin{Manifest: Manifest{Entrypoint: "run.sh", RequiredEnv: []string{"RALPH_ML_TEST_PRESENT", "RALPH_ML_TEST_MISSING"}}}
+
+	if _, err := p.Env(); err == nil {
+		t.Error("Expected an error for a missing required env var")
+	} else if !strings.Contains(err.Error(), "RALPH_ML_TEST_MISSING") {
+		t.Errorf("Expected the error to name the missing var, got: %v", err)
+	}
+}
+
+func TestInstallRejectsURL(t *testing.T) {
+	if _, err := Install("https://example.com/plugin"); err == nil {
+		t.Error("Expected an error installing from a URL")
+	}
+}
+
+func TestRegistryResolve(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeManifest(t, filepath.Join(tmpDir, "claude"), Manifest{Name: "claude", Version: "1.0.0", Entrypoint: "run.sh"})
+
+	r := &Registry{plugins: map[string]Plugin{}}
+	if err := r.discover(tmpDir); err != nil {
+		t.Fatalf("Failed to discover plugins: %v", err)
+	}
+
+	plugin, ok := r.Resolve("claude")
+	if !ok {
+		t.Fatal("Expected plugin 'claude' to be resolved")
+	}
+	if plugin.Manifest.Version != "1.0.0" {
+		t.Errorf("Expected version '1.0.0', got '%s'", plugin.Manifest.Version)
+	}
+
+	if _, ok := r.Resolve("opencode"); ok {
+		t.Error("Expected 'opencode' to not resolve to a plugin (built-in runner)")
+	}
+}
+
+func TestInstallAndRemove(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	srcDir := t.TempDir()
+	writeManifest(t, srcDir, Manifest{Name: "aider", Version: "2.0.0", Entrypoint: "run.sh"})
+	if err := os.WriteFile(filepath.Join(srcDir, "run.sh"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("failed to write entrypoint: %v", err)
+	}
+
+	manifest, err := Install(srcDir)
+	if err != nil {
+		t.Fatalf("Failed to install plugin: %v", err)
+	}
+	if manifest.Name != "aider" {
+		t.Errorf("Expected name 'aider', got '%s'", manifest.Name)
+	}
+
+	userDir, _ := UserPluginsDir()
+	if _, err := os.Stat(filepath.Join(userDir, "aider", "run.sh")); err != nil {
+		t.Errorf("Expected entrypoint to be copied: %v", err)
+	}
+
+	if err := Remove("aider"); err != nil {
+		t.Fatalf("Failed to remove plugin: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(userDir, "aider")); !os.IsNotExist(err) {
+		t.Error("Expected plugin directory to be removed")
+	}
+}
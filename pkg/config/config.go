@@ -5,80 +5,115 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
 )
 
+// Format identifies a config file's on-disk serialization.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatTOML Format = "toml"
+	FormatYAML Format = "yaml"
+)
+
+// FormatFromExt maps a config file's extension to a Format, defaulting to
+// JSON for unrecognized or missing extensions.
+func FormatFromExt(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return FormatTOML
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatJSON
+	}
+}
+
 // TargetMetric represents a target metric definition.
 type TargetMetric struct {
-	Name     string  `json:"name"`
-	Value    float64 `json:"value"`
-	Direction string `json:"direction,omitempty"` // maximize, minimize
+	Name      string  `json:"name" toml:"name" yaml:"name"`
+	Value     float64 `json:"value" toml:"value" yaml:"value"`
+	Direction string  `json:"direction,omitempty" toml:"direction,omitempty" yaml:"direction,omitempty"` // maximize, minimize
 }
 
 // ProjectConfig represents project configuration.
 type ProjectConfig struct {
-	Name        string       `json:"name"`
-	Framework   string       `json:"framework,omitempty"`
-	Task        string       `json:"task,omitempty"`
-	TargetMetric TargetMetric `json:"target_metric"`
+	Name         string       `json:"name" toml:"name" yaml:"name"`
+	Framework    string       `json:"framework,omitempty" toml:"framework,omitempty" yaml:"framework,omitempty"`
+	Task         string       `json:"task,omitempty" toml:"task,omitempty" yaml:"task,omitempty"`
+	TargetMetric TargetMetric `json:"target_metric" toml:"target_metric" yaml:"target_metric"`
 }
 
 // DataConfig represents data configuration.
 type DataConfig struct {
-	Root      string `json:"root,omitempty"`
-	TrainSplit string `json:"train_split,omitempty"`
-	ValSplit   string `json:"val_split,omitempty"`
-	TestSplit  string `json:"test_split,omitempty"`
+	Root       string `json:"root,omitempty" toml:"root,omitempty" yaml:"root,omitempty"`
+	TrainSplit string `json:"train_split,omitempty" toml:"train_split,omitempty" yaml:"train_split,omitempty"`
+	ValSplit   string `json:"val_split,omitempty" toml:"val_split,omitempty" yaml:"val_split,omitempty"`
+	TestSplit  string `json:"test_split,omitempty" toml:"test_split,omitempty" yaml:"test_split,omitempty"`
 }
 
 // SafeguardsConfig represents safeguards configuration.
 type SafeguardsConfig struct {
-	MaxCycles              int     `json:"max_cycles,omitempty"`
-	NoImprovementStopCycles int     `json:"no_improvement_stop_cycles,omitempty"`
-	MinImprovementDelta    float64 `json:"min_improvement_delta,omitempty"`
-	TimeLimitPerCycleMinutes int    `json:"time_limit_per_cycle_minutes,omitempty"`
-	TokenBudgetPerCycle    int     `json:"token_budget_per_cycle,omitempty"`
+	MaxCycles                int     `json:"max_cycles,omitempty" toml:"max_cycles,omitempty" yaml:"max_cycles,omitempty"`
+	NoImprovementStopCycles  int     `json:"no_improvement_stop_cycles,omitempty" toml:"no_improvement_stop_cycles,omitempty" yaml:"no_improvement_stop_cycles,omitempty"`
+	MinImprovementDelta      float64 `json:"min_improvement_delta,omitempty" toml:"min_improvement_delta,omitempty" yaml:"min_improvement_delta,omitempty"`
+	TimeLimitPerCycleMinutes int     `json:"time_limit_per_cycle_minutes,omitempty" toml:"time_limit_per_cycle_minutes,omitempty" yaml:"time_limit_per_cycle_minutes,omitempty"`
+	TokenBudgetPerCycle      int     `json:"token_budget_per_cycle,omitempty" toml:"token_budget_per_cycle,omitempty" yaml:"token_budget_per_cycle,omitempty"`
 }
 
 // ExecutionConfig represents execution configuration.
 type ExecutionConfig struct {
-	Mode        string `json:"mode,omitempty"`
-	Python      string `json:"python,omitempty"`
-	TrainCmd    string `json:"train_cmd,omitempty"`
-	EvalCmd     string `json:"eval_cmd,omitempty"`
-	EnvCapture  bool   `json:"env_capture,omitempty"`
+	Mode       string `json:"mode,omitempty" toml:"mode,omitempty" yaml:"mode,omitempty"`
+	Python     string `json:"python,omitempty" toml:"python,omitempty" yaml:"python,omitempty"`
+	TrainCmd   string `json:"train_cmd,omitempty" toml:"train_cmd,omitempty" yaml:"train_cmd,omitempty"`
+	EvalCmd    string `json:"eval_cmd,omitempty" toml:"eval_cmd,omitempty" yaml:"eval_cmd,omitempty"`
+	EnvCapture bool   `json:"env_capture,omitempty" toml:"env_capture,omitempty" yaml:"env_capture,omitempty"`
 }
 
 // AgentsConfig represents agent configuration.
 type AgentsConfig struct {
-	CodeModel     string `json:"code_model,omitempty"`
-	AnalysisModel string `json:"analysis_model,omitempty"`
-	Thinking      string `json:"thinking,omitempty"`
+	CodeModel     string `json:"code_model,omitempty" toml:"code_model,omitempty" yaml:"code_model,omitempty"`
+	AnalysisModel string `json:"analysis_model,omitempty" toml:"analysis_model,omitempty" yaml:"analysis_model,omitempty"`
+	Thinking      string `json:"thinking,omitempty" toml:"thinking,omitempty" yaml:"thinking,omitempty"`
 }
 
 // PathsConfig represents path configuration.
 type PathsConfig struct {
-	Workspace string `json:"workspace,omitempty"`
-	Runs      string `json:"runs,omitempty"`
-	Reports   string `json:"reports,omitempty"`
-	State     string `json:"state,omitempty"`
+	Workspace string `json:"workspace,omitempty" toml:"workspace,omitempty" yaml:"workspace,omitempty"`
+	Runs      string `json:"runs,omitempty" toml:"runs,omitempty" yaml:"runs,omitempty"`
+	Reports   string `json:"reports,omitempty" toml:"reports,omitempty" yaml:"reports,omitempty"`
+	State     string `json:"state,omitempty" toml:"state,omitempty" yaml:"state,omitempty"`
+}
+
+// PrometheusConfig represents Prometheus metrics configuration.
+type PrometheusConfig struct {
+	Enable         bool   `json:"enable,omitempty" toml:"enable,omitempty" yaml:"enable,omitempty"`
+	ListenAddr     string `json:"listen_addr,omitempty" toml:"listen_addr,omitempty" yaml:"listen_addr,omitempty"`
+	Path           string `json:"path,omitempty" toml:"path,omitempty" yaml:"path,omitempty"`
+	PushGatewayURL string `json:"push_gateway_url,omitempty" toml:"push_gateway_url,omitempty" yaml:"push_gateway_url,omitempty"`
 }
 
 // ObservabilityConfig represents observability configuration.
 type ObservabilityConfig struct {
-	Logger          string `json:"logger,omitempty"`
-	SaveStdout      bool   `json:"save_stdout,omitempty"`
-	EmitEventsJsonl bool   `json:"emit_events_jsonl,omitempty"`
+	Logger          string           `json:"logger,omitempty" toml:"logger,omitempty" yaml:"logger,omitempty"`
+	SaveStdout      bool             `json:"save_stdout,omitempty" toml:"save_stdout,omitempty" yaml:"save_stdout,omitempty"`
+	EmitEventsJsonl bool             `json:"emit_events_jsonl,omitempty" toml:"emit_events_jsonl,omitempty" yaml:"emit_events_jsonl,omitempty"`
+	Prometheus      PrometheusConfig `json:"prometheus,omitempty" toml:"prometheus,omitempty" yaml:"prometheus,omitempty"`
 }
 
 // Config represents the full Ralph ML Loop configuration.
 type Config struct {
-	Project        ProjectConfig        `json:"project"`
-	Data           DataConfig           `json:"data,omitempty"`
-	Safeguards     SafeguardsConfig     `json:"safeguards,omitempty"`
-	Execution      ExecutionConfig      `json:"execution,omitempty"`
-	Agents         AgentsConfig         `json:"agents,omitempty"`
-	Paths          PathsConfig          `json:"paths,omitempty"`
-	Observability  ObservabilityConfig  `json:"observability,omitempty"`
+	Project       ProjectConfig       `json:"project" toml:"project" yaml:"project"`
+	Data          DataConfig          `json:"data,omitempty" toml:"data,omitempty" yaml:"data,omitempty"`
+	Safeguards    SafeguardsConfig    `json:"safeguards,omitempty" toml:"safeguards,omitempty" yaml:"safeguards,omitempty"`
+	Execution     ExecutionConfig     `json:"execution,omitempty" toml:"execution,omitempty" yaml:"execution,omitempty"`
+	Agents        AgentsConfig        `json:"agents,omitempty" toml:"agents,omitempty" yaml:"agents,omitempty"`
+	Paths         PathsConfig         `json:"paths,omitempty" toml:"paths,omitempty" yaml:"paths,omitempty"`
+	Observability ObservabilityConfig `json:"observability,omitempty" toml:"observability,omitempty" yaml:"observability,omitempty"`
 }
 
 // NewDefaultConfig creates a default configuration.
@@ -127,37 +162,83 @@ func NewDefaultConfig() *Config {
 			Logger:          "tensorboard",
 			SaveStdout:      true,
 			EmitEventsJsonl: true,
+			Prometheus: PrometheusConfig{
+				Enable:     false,
+				ListenAddr: ":9090",
+				Path:       "/metrics",
+			},
 		},
 	}
 }
 
-// LoadConfig loads a configuration from a JSON file.
+// LoadConfig loads a configuration from a file, dispatching on its
+// extension (.json, .toml, .yaml/.yml; unrecognized extensions are treated
+// as JSON).
 func LoadConfig(path string) (*Config, error) {
+	return LoadConfigFormat(path, FormatFromExt(path))
+}
+
+// LoadConfigFormat loads a configuration from path, parsed with the given
+// Format regardless of the file's extension.
+func LoadConfigFormat(path string, format Format) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	var config Config
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	var cfg Config
+	switch format {
+	case FormatTOML:
+		if err := toml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+	case FormatYAML:
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+	default:
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
 	}
 
-	return &config, nil
+	return &cfg, nil
 }
 
-// SaveConfig saves a configuration to a JSON file.
+// SaveConfig saves a configuration to a file, dispatching on its extension
+// (.json, .toml, .yaml/.yml; unrecognized extensions are treated as JSON).
 func SaveConfig(path string, config *Config) error {
+	return SaveConfigFormat(path, config, FormatFromExt(path))
+}
+
+// SaveConfigFormat saves a configuration to path, serialized with the given
+// Format regardless of the file's extension.
+func SaveConfigFormat(path string, config *Config, format Format) error {
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	// Marshal with indentation
-	data, err := json.MarshalIndent(config, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+	var data []byte
+	var err error
+	switch format {
+	case FormatTOML:
+		var buf strings.Builder
+		if err := toml.NewEncoder(&buf).Encode(config); err != nil {
+			return fmt.Errorf("failed to marshal config: %w", err)
+		}
+		data = []byte(buf.String())
+	case FormatYAML:
+		data, err = yaml.Marshal(config)
+		if err != nil {
+			return fmt.Errorf("failed to marshal config: %w", err)
+		}
+	default:
+		data, err = json.MarshalIndent(config, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal config: %w", err)
+		}
 	}
 
 	// Write file
@@ -177,6 +258,55 @@ func LoadConfigFromJSON(data []byte) (*Config, error) {
 	return &config, nil
 }
 
+// Environment variable names recognized by ApplyEnvOverrides. These take
+// precedence over CLI flags, config file values, and built-in defaults, so
+// CI/container users can redirect a run without editing JSON or flags.
+const (
+	EnvWorkspaceDir = "RALPH_ML_WORKSPACE_DIR"
+	EnvRunsDir      = "RALPH_ML_RUNS_DIR"
+	EnvReportsDir   = "RALPH_ML_REPORTS_DIR"
+	EnvStateDir     = "RALPH_ML_STATE_DIR"
+	EnvDataRoot     = "RALPH_ML_DATA_ROOT"
+	EnvMode         = "RALPH_ML_MODE"
+	EnvPython       = "RALPH_ML_PYTHON"
+	EnvTrainCmd     = "RALPH_ML_TRAIN_CMD"
+	EnvEvalCmd      = "RALPH_ML_EVAL_CMD"
+)
+
+// ApplyEnvOverrides applies environment variable overrides to cfg in place.
+// It must be called last, after config file loading and CLI flag overrides,
+// since env vars take the highest precedence (env > CLI flag > config file >
+// default).
+func ApplyEnvOverrides(cfg *Config) {
+	if v := os.Getenv(EnvWorkspaceDir); v != "" {
+		cfg.Paths.Workspace = v
+	}
+	if v := os.Getenv(EnvRunsDir); v != "" {
+		cfg.Paths.Runs = v
+	}
+	if v := os.Getenv(EnvReportsDir); v != "" {
+		cfg.Paths.Reports = v
+	}
+	if v := os.Getenv(EnvStateDir); v != "" {
+		cfg.Paths.State = v
+	}
+	if v := os.Getenv(EnvDataRoot); v != "" {
+		cfg.Data.Root = v
+	}
+	if v := os.Getenv(EnvMode); v != "" {
+		cfg.Execution.Mode = v
+	}
+	if v := os.Getenv(EnvPython); v != "" {
+		cfg.Execution.Python = v
+	}
+	if v := os.Getenv(EnvTrainCmd); v != "" {
+		cfg.Execution.TrainCmd = v
+	}
+	if v := os.Getenv(EnvEvalCmd); v != "" {
+		cfg.Execution.EvalCmd = v
+	}
+}
+
 // CreateDirectories creates all directories specified in the config.
 func (c *Config) CreateDirectories() error {
 	dirs := []string{
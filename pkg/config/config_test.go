@@ -71,6 +71,52 @@ func TestSaveAndLoadConfig(t *testing.T) {
 	}
 }
 
+func TestSaveAndLoadConfigFormats(t *testing.T) {
+	for _, format := range []Format{FormatJSON, FormatTOML, FormatYAML} {
+		format := format
+		t.Run(string(format), func(t *testing.T) {
+			tmpDir := t.TempDir()
+			configPath := filepath.Join(tmpDir, "config."+string(format))
+
+			cfg := NewDefaultConfig()
+			cfg.Project.Name = "roundtrip-" + string(format)
+			cfg.Safeguards.MaxCycles = 7
+
+			if err := SaveConfig(configPath, cfg); err != nil {
+				t.Fatalf("Failed to save %s config: %v", format, err)
+			}
+
+			loaded, err := LoadConfig(configPath)
+			if err != nil {
+				t.Fatalf("Failed to load %s config: %v", format, err)
+			}
+
+			if loaded.Project.Name != cfg.Project.Name {
+				t.Errorf("Expected project name %q, got %q", cfg.Project.Name, loaded.Project.Name)
+			}
+			if loaded.Safeguards.MaxCycles != 7 {
+				t.Errorf("Expected max cycles 7, got %d", loaded.Safeguards.MaxCycles)
+			}
+		})
+	}
+}
+
+func TestFormatFromExt(t *testing.T) {
+	tests := map[string]Format{
+		"config.json": FormatJSON,
+		"config.toml": FormatTOML,
+		"config.yaml": FormatYAML,
+		"config.yml":  FormatYAML,
+		"config":      FormatJSON,
+	}
+
+	for path, want := range tests {
+		if got := FormatFromExt(path); got != want {
+			t.Errorf("FormatFromExt(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
 func TestLoadConfigFromJSON(t *testing.T) {
 	cfg := NewDefaultConfig()
 	cfg.Project.Name = "json-test"
@@ -91,6 +137,65 @@ func TestLoadConfigFromJSON(t *testing.T) {
 	}
 }
 
+func TestApplyEnvOverrides(t *testing.T) {
+	envVars := map[string]string{
+		EnvWorkspaceDir: "/env/workspace",
+		EnvRunsDir:      "/env/runs",
+		EnvReportsDir:   "/env/reports",
+		EnvStateDir:     "/env/state",
+		EnvDataRoot:     "/env/data",
+		EnvMode:         "remote",
+		EnvPython:       "/env/bin/python3",
+		EnvTrainCmd:     "python train.py --env",
+		EnvEvalCmd:      "python eval.py --env",
+	}
+
+	for k, v := range envVars {
+		t.Setenv(k, v)
+	}
+
+	cfg := NewDefaultConfig()
+	ApplyEnvOverrides(cfg)
+
+	if cfg.Paths.Workspace != "/env/workspace" {
+		t.Errorf("Expected workspace '/env/workspace', got '%s'", cfg.Paths.Workspace)
+	}
+	if cfg.Paths.Runs != "/env/runs" {
+		t.Errorf("Expected runs '/env/runs', got '%s'", cfg.Paths.Runs)
+	}
+	if cfg.Paths.Reports != "/env/reports" {
+		t.Errorf("Expected reports '/env/reports', got '%s'", cfg.Paths.Reports)
+	}
+	if cfg.Paths.State != "/env/state" {
+		t.Errorf("Expected state '/env/state', got '%s'", cfg.Paths.State)
+	}
+	if cfg.Data.Root != "/env/data" {
+		t.Errorf("Expected data root '/env/data', got '%s'", cfg.Data.Root)
+	}
+	if cfg.Execution.Mode != "remote" {
+		t.Errorf("Expected mode 'remote', got '%s'", cfg.Execution.Mode)
+	}
+	if cfg.Execution.Python != "/env/bin/python3" {
+		t.Errorf("Expected python '/env/bin/python3', got '%s'", cfg.Execution.Python)
+	}
+	if cfg.Execution.TrainCmd != "python train.py --env" {
+		t.Errorf("Expected train cmd override, got '%s'", cfg.Execution.TrainCmd)
+	}
+	if cfg.Execution.EvalCmd != "python eval.py --env" {
+		t.Errorf("Expected eval cmd override, got '%s'", cfg.Execution.EvalCmd)
+	}
+}
+
+func TestApplyEnvOverridesNoop(t *testing.T) {
+	cfg := NewDefaultConfig()
+	want := *cfg
+	ApplyEnvOverrides(cfg)
+
+	if cfg.Paths.Workspace != want.Paths.Workspace || cfg.Execution.Python != want.Execution.Python {
+		t.Error("ApplyEnvOverrides should not change config when no env vars are set")
+	}
+}
+
 func TestCreateDirectories(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -1,38 +1,113 @@
 package display
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/matthiaspetry/DeepLoop/cli/pkg/paths"
 	"github.com/matthiaspetry/DeepLoop/cli/pkg/state"
 )
 
+// OutputMode controls whether display output is human-readable text or
+// line-delimited JSON events, for programmatic callers (schedulers,
+// notebooks) that can't parse the emoji-decorated stdout.
+type OutputMode string
+
+const (
+	OutputHuman OutputMode = "human"
+	OutputJSON  OutputMode = "json"
+)
+
+var mode = OutputHuman
+
+// SetOutputMode switches all subsequent display output (Success, Error,
+// Info, Warning, Progress) to the given mode.
+func SetOutputMode(m OutputMode) {
+	mode = m
+}
+
+// Event is a single line-delimited JSON event emitted when OutputMode is
+// OutputJSON.
+type Event struct {
+	Level   string `json:"level"`
+	Message string `json:"message"`
+}
+
+func emit(w io.Writer, level, emoji, message string) {
+	if mode == OutputJSON {
+		data, err := json.Marshal(Event{Level: level, Message: message})
+		if err != nil {
+			return
+		}
+		fmt.Fprintln(w, string(data))
+		return
+	}
+	fmt.Fprintf(w, "%s %s\n", emoji, message)
+}
+
 // Success prints a success message with a checkmark.
 func Success(message string) {
-	fmt.Printf("✅ %s\n", message)
+	emit(os.Stdout, "success", "✅", message)
 }
 
 // Warning prints a warning message.
 func Warning(message string) {
-	fmt.Printf("⚠️  %s\n", message)
+	emit(os.Stdout, "warning", "⚠️ ", message)
 }
 
 // Error prints an error message to stderr.
 func Error(message string) {
-	fmt.Fprintf(os.Stderr, "❌ %s\n", message)
+	emit(os.Stderr, "error", "❌", message)
 }
 
 // Info prints an informational message.
 func Info(message string) {
-	fmt.Printf("ℹ️  %s\n", message)
+	emit(os.Stdout, "info", "ℹ️ ", message)
 }
 
 // Progress prints a progress indicator.
 func Progress(message string) {
-	fmt.Printf("🔄 %s\n", message)
+	emit(os.Stdout, "progress", "🔄", message)
+}
+
+// eventWriter wraps an io.Writer so every line written to it is emitted as a
+// JSON event instead of raw text, used to carry orchestrator subprocess
+// output through --output json.
+type eventWriter struct {
+	event string
+	out   io.Writer
+}
+
+// NewEventWriter returns an io.Writer that, when OutputMode is OutputJSON,
+// wraps each line written to it as a JSON event tagged with the given event
+// name. In human mode it writes lines through unchanged.
+func NewEventWriter(event string, out io.Writer) io.Writer {
+	return &eventWriter{event: event, out: out}
+}
+
+type lineEvent struct {
+	Event   string `json:"event"`
+	Message string `json:"message"`
+}
+
+func (w *eventWriter) Write(p []byte) (int, error) {
+	if mode != OutputJSON {
+		return w.out.Write(p)
+	}
+
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		data, err := json.Marshal(lineEvent{Event: w.event, Message: line})
+		if err != nil {
+			continue
+		}
+		fmt.Fprintln(w.out, string(data))
+	}
+	return len(p), nil
 }
 
 // PrintRunsTable prints a table of runs.
@@ -79,10 +154,27 @@ func PrintState(st *state.State, statePath string) {
 	if st.LastUpdate != nil {
 		fmt.Printf("   Last update: %s\n", state.FormatTime(*st.LastUpdate))
 	}
+
+	if labels := st.Labels(); len(labels) > 0 {
+		keys := make([]string, 0, len(labels))
+		for k := range labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		fmt.Print("   Labels:\n")
+		for _, k := range keys {
+			fmt.Printf("     %s=%s\n", k, labels[k])
+		}
+	}
 }
 
 // PrintSection prints a section header.
 func PrintSection(title string) {
+	if mode == OutputJSON {
+		emit(os.Stdout, "section", "", title)
+		return
+	}
 	fmt.Printf("\n%s\n", title)
 	fmt.Println(strings.Repeat("=", len(title)))
 }
@@ -107,6 +199,34 @@ func PrintWindowsNote() {
 	}
 }
 
+// PrintProgressBar renders a single-line, redrawing progress bar showing
+// cycle and best-metric progress against target. Each call starts with "\r"
+// and prints no trailing newline, so successive calls overwrite the same
+// terminal line; callers print a newline after the last one.
+func PrintProgressBar(cycle, maxCycles int, best *float64, target float64) {
+	width := 30
+	filled := 0
+	if maxCycles > 0 {
+		filled = width * cycle / maxCycles
+		if filled > width {
+			filled = width
+		}
+	}
+
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", width-filled)
+
+	bestStr := "N/A"
+	if best != nil {
+		bestStr = fmt.Sprintf("%.4f", *best)
+	}
+
+	if maxCycles > 0 {
+		fmt.Printf("\r[%s] cycle %d/%d  best=%s  target=%.4f", bar, cycle, maxCycles, bestStr, target)
+	} else {
+		fmt.Printf("\r[%s] cycle %d  best=%s  target=%.4f", bar, cycle, bestStr, target)
+	}
+}
+
 // PrintPythonNotFound prints helpful message when Python is not found.
 func PrintPythonNotFound() {
 	Error("Python not found or not accessible")
@@ -0,0 +1,216 @@
+// Package metrics exposes Prometheus instrumentation for the run loop:
+// cycle counts, durations, best metric value, token usage, and orchestrator
+// subprocess exit codes.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// Collectors holds the metric families registered for a run. Callers update
+// them from the orchestrator/cycle loop and register them once via NewCollectors.
+type Collectors struct {
+	registry *prometheus.Registry
+
+	CyclesStarted   prometheus.Counter
+	CyclesSucceeded prometheus.Counter
+	CyclesTotal     prometheus.Counter
+	CurrentCycle    prometheus.Gauge
+	CycleDuration   prometheus.Histogram
+	BestMetricValue prometheus.Gauge
+	BestMetric      prometheus.Gauge
+	TokensUsed      prometheus.Histogram
+	SubprocessExits *prometheus.CounterVec
+	StateSaveErrors prometheus.Counter
+
+	// snapMu guards the plain fields below, which mirror the instruments
+	// above so Snapshot can report current values without depending on
+	// registry.Gather's dto shape. Kept in lockstep by Inc*/Set* helpers.
+	snapMu sync.Mutex
+	snap   Snapshot
+}
+
+// NewCollectors creates and registers the standard set of run-loop metrics
+// against a fresh registry.
+func NewCollectors() *Collectors {
+	registry := prometheus.NewRegistry()
+
+	c := &Collectors{
+		registry: registry,
+		CyclesStarted: promauto.With(registry).NewCounter(prometheus.CounterOpts{
+			Name: "deeploop_cycles_started_total",
+			Help: "Total number of optimization cycles started.",
+		}),
+		CyclesSucceeded: promauto.With(registry).NewCounter(prometheus.CounterOpts{
+			Name: "deeploop_cycles_succeeded_total",
+			Help: "Total number of optimization cycles that completed successfully.",
+		}),
+		CyclesTotal: promauto.With(registry).NewCounter(prometheus.CounterOpts{
+			Name: "deeploop_cycles_total",
+			Help: "Total number of optimization cycles attempted, regardless of outcome.",
+		}),
+		CurrentCycle: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "deeploop_current_cycle",
+			Help: "The cycle number this run is currently on.",
+		}),
+		CycleDuration: promauto.With(registry).NewHistogram(prometheus.HistogramOpts{
+			Name:    "deeploop_cycle_duration_seconds",
+			Help:    "Duration of a single optimization cycle, in seconds.",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 12), // 1s .. ~34min
+		}),
+		BestMetricValue: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "deeploop_best_metric_value",
+			Help: "Best target metric value observed so far in the run.",
+		}),
+		BestMetric: promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+			Name: "deeploop_best_metric",
+			Help: "Best target metric value observed so far in the run (alias of deeploop_best_metric_value for dashboards built against the shorter name).",
+		}),
+		TokensUsed: promauto.With(registry).NewHistogram(prometheus.HistogramOpts{
+			Name:    "deeploop_tokens_used",
+			Help:    "Tokens consumed per cycle by the code/analysis agent.",
+			Buckets: prometheus.ExponentialBuckets(1000, 2, 10),
+		}),
+		SubprocessExits: promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+			Name: "deeploop_orchestrator_subprocess_exits_total",
+			Help: "Orchestrator subprocess exits, labeled by exit code.",
+		}, []string{"exit_code"}),
+		StateSaveErrors: promauto.With(registry).NewCounter(prometheus.CounterOpts{
+			Name: "deeploop_state_save_errors_total",
+			Help: "Total number of failed attempts to save run state to disk.",
+		}),
+	}
+
+	return c
+}
+
+// IncCyclesTotal increments the total-cycles counter and its multiprocess
+// snapshot mirror.
+func (c *Collectors) IncCyclesTotal() {
+	c.CyclesTotal.Inc()
+	c.snapMu.Lock()
+	c.snap.CyclesTotal++
+	c.snapMu.Unlock()
+}
+
+// SetCurrentCycle records the cycle number this run is currently on.
+func (c *Collectors) SetCurrentCycle(cycle int) {
+	c.CurrentCycle.Set(float64(cycle))
+	c.snapMu.Lock()
+	c.snap.CurrentCycle = float64(cycle)
+	c.snapMu.Unlock()
+}
+
+// SetBestMetric records the best target metric value observed so far,
+// updating both deeploop_best_metric_value and deeploop_best_metric.
+func (c *Collectors) SetBestMetric(value float64) {
+	c.BestMetricValue.Set(value)
+	c.BestMetric.Set(value)
+	c.snapMu.Lock()
+	c.snap.BestMetric = value
+	c.snapMu.Unlock()
+}
+
+// IncStateSaveErrors increments the state-save-error counter and its
+// multiprocess snapshot mirror.
+func (c *Collectors) IncStateSaveErrors() {
+	c.StateSaveErrors.Inc()
+	c.snapMu.Lock()
+	c.snap.StateSaveErrors++
+	c.snapMu.Unlock()
+}
+
+// Snapshot returns the current values of the metrics tracked for
+// multiprocess aggregation (see WriteMultiprocSnapshot).
+func (c *Collectors) Snapshot() Snapshot {
+	c.snapMu.Lock()
+	defer c.snapMu.Unlock()
+	return c.snap
+}
+
+// ObserveExit increments the subprocess exit counter for the given code.
+func (c *Collectors) ObserveExit(code int) {
+	c.SubprocessExits.WithLabelValues(fmt.Sprintf("%d", code)).Inc()
+}
+
+// Server serves /metrics for the lifetime of a run and can optionally push a
+// final snapshot to a Pushgateway so ephemeral runs still get scraped.
+type Server struct {
+	collectors *Collectors
+	httpServer *http.Server
+	pusher     *push.Pusher
+}
+
+// NewServer binds listenAddr and starts an HTTP server exposing
+// cfg-configured metrics, returning an error immediately if the listener
+// can't be bound (e.g. the port is already in use) instead of only failing
+// silently in the background. Callers must call Shutdown when the run ends.
+func NewServer(collectors *Collectors, listenAddr, path string) (*Server, error) {
+	if path == "" {
+		path = "/metrics"
+	}
+
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind metrics listener on %s: %w", listenAddr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(path, promhttp.HandlerFor(collectors.registry, promhttp.HandlerOpts{}))
+
+	s := &Server{
+		collectors: collectors,
+		httpServer: &http.Server{Addr: listenAddr, Handler: mux},
+	}
+
+	go func() {
+		// Serve only returns once Shutdown closes the listener (reported as
+		// http.ErrServerClosed, expected) or the listener fails in some
+		// other way after a successful bind above (rare, but worth logging
+		// instead of discarding like the previous ListenAndServe call did).
+		if err := s.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			fmt.Fprintf(os.Stderr, "metrics server stopped unexpectedly: %v\n", err)
+		}
+	}()
+
+	return s, nil
+}
+
+// EnablePush configures a Pushgateway target that PushFinal will push to.
+func (s *Server) EnablePush(gatewayURL, jobName string) {
+	s.pusher = push.New(gatewayURL, jobName).Gatherer(s.collectors.registry)
+}
+
+// PushFinal pushes the current metric snapshot to the configured
+// Pushgateway, so ephemeral runs still get scraped after the process exits.
+func (s *Server) PushFinal() error {
+	if s.pusher == nil {
+		return nil
+	}
+	return s.pusher.Push()
+}
+
+// Shutdown gracefully stops the metrics HTTP server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
+
+// TimeCycle returns a function that, when called, observes the elapsed time
+// in CycleDuration. Typical use: `done := c.TimeCycle(); defer done()`.
+func (c *Collectors) TimeCycle() func() {
+	start := time.Now()
+	return func() {
+		c.CycleDuration.Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func TestMetricsFamiliesPresent(t *testing.T) {
+	collectors := NewCollectors()
+	collectors.CyclesStarted.Inc()
+	collectors.CyclesSucceeded.Inc()
+	collectors.IncCyclesTotal()
+	collectors.SetCurrentCycle(3)
+	collectors.CycleDuration.Observe(12.5)
+	collectors.BestMetricValue.Set(0.93)
+	collectors.SetBestMetric(0.93)
+	collectors.TokensUsed.Observe(4200)
+	collectors.ObserveExit(0)
+	collectors.IncStateSaveErrors()
+
+	server := httptest.NewServer(promhttp.HandlerFor(collectors.registry, promhttp.HandlerOpts{}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to scrape metrics endpoint: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read metrics response: %v", err)
+	}
+
+	want := []string{
+		"deeploop_cycles_started_total",
+		"deeploop_cycles_succeeded_total",
+		"deeploop_cycles_total",
+		"deeploop_current_cycle",
+		"deeploop_cycle_duration_seconds",
+		"deeploop_best_metric_value",
+		"deeploop_best_metric",
+		"deeploop_tokens_used",
+		"deeploop_orchestrator_subprocess_exits_total",
+		"deeploop_state_save_errors_total",
+	}
+
+	out := string(body)
+	for _, name := range want {
+		if !strings.Contains(out, name) {
+			t.Errorf("Expected metric family %q in scrape output", name)
+		}
+	}
+}
+
+func TestNewServerServesMetrics(t *testing.T) {
+	collectors := NewCollectors()
+	collectors.IncCyclesTotal()
+
+	server, err := NewServer(collectors, "127.0.0.1:0", "/metrics")
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	defer server.Shutdown(context.Background())
+}
+
+func TestNewServerErrorsWhenAddrAlreadyInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to reserve a port: %v", err)
+	}
+	defer listener.Close()
+
+	if _, err := NewServer(NewCollectors(), listener.Addr().String(), ""); err == nil {
+		t.Error("Expected NewServer to return an error for an address already in use")
+	}
+}
+
+func TestObserveExitLabelsByCode(t *testing.T) {
+	collectors := NewCollectors()
+	collectors.ObserveExit(1)
+	collectors.ObserveExit(1)
+	collectors.ObserveExit(0)
+
+	server := httptest.NewServer(promhttp.HandlerFor(collectors.registry, promhttp.HandlerOpts{}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to scrape metrics endpoint: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	out := string(body)
+
+	if !strings.Contains(out, `exit_code="1"} 2`) {
+		t.Errorf("Expected exit_code=1 counted twice, got:\n%s", out)
+	}
+	if !strings.Contains(out, `exit_code="0"} 1`) {
+		t.Errorf("Expected exit_code=0 counted once, got:\n%s", out)
+	}
+}
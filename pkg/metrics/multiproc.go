@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// MultiprocDirEnv is the environment variable naming a shared directory that
+// each `ralph-ml start` invocation writes its metric snapshot into, and that a
+// sidecar `ralph-ml metrics serve` process aggregates across. The name
+// matches Python's prometheus_client multiprocess convention so both sides
+// of this tool can point at the same directory.
+const MultiprocDirEnv = "PROMETHEUS_MULTIPROC_DIR"
+
+// Snapshot is one process's current value for each multiprocess-aggregated
+// metric, as written to MultiprocDirEnv for `metrics serve` to combine
+// across concurrent runs.
+type Snapshot struct {
+	CyclesTotal     float64 `json:"cycles_total"`
+	CurrentCycle    float64 `json:"current_cycle"`
+	BestMetric      float64 `json:"best_metric"`
+	StateSaveErrors float64 `json:"state_save_errors_total"`
+}
+
+// snapshotFile returns the path this process writes its snapshot to: one
+// file per PID, so concurrent runs never clobber each other and a dead
+// process's last values simply stop changing.
+func snapshotFile(dir string) string {
+	return filepath.Join(dir, fmt.Sprintf("deeploop-%d.json", os.Getpid()))
+}
+
+// WriteMultiprocSnapshot writes snap to dir for this process, atomically so
+// a concurrent `metrics serve` scrape never reads a half-written file.
+func WriteMultiprocSnapshot(dir string, snap Snapshot) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create multiprocess metrics dir: %w", err)
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metrics snapshot: %w", err)
+	}
+
+	path := snapshotFile(dir)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write metrics snapshot: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to rename metrics snapshot into place: %w", err)
+	}
+
+	return nil
+}
+
+// WriteMultiprocSnapshot writes c's current Snapshot to dir for this
+// process. Callers typically call this once on exit, after the run's final
+// cycle/state-save outcome is known.
+func (c *Collectors) WriteMultiprocSnapshot(dir string) error {
+	return WriteMultiprocSnapshot(dir, c.Snapshot())
+}
+
+// readMultiprocSnapshots reads every per-process snapshot file in dir,
+// skipping ones that fail to parse (e.g. a concurrent partial write) rather
+// than failing the whole scrape.
+func readMultiprocSnapshots(dir string) ([]Snapshot, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read multiprocess metrics dir: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	snapshots := make([]Snapshot, 0, len(names))
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		var snap Snapshot
+		if err := json.Unmarshal(data, &snap); err != nil {
+			continue
+		}
+		snapshots = append(snapshots, snap)
+	}
+
+	return snapshots, nil
+}
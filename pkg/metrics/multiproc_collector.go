@@ -0,0 +1,71 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// multiprocCyclesTotal etc. describe the metric families MultiprocCollector
+// exposes; kept separate from Collectors' own descriptors since this
+// collector reads from snapshot files on disk rather than in-process state.
+var (
+	multiprocCyclesTotal = prometheus.NewDesc(
+		"deeploop_cycles_total", "Total number of optimization cycles attempted, summed across concurrent runs.", nil, nil)
+	multiprocCurrentCycle = prometheus.NewDesc(
+		"deeploop_current_cycle", "Highest cycle number reached by any currently reporting run.", nil, nil)
+	multiprocBestMetric = prometheus.NewDesc(
+		"deeploop_best_metric", "Best target metric value observed across concurrent runs.", nil, nil)
+	multiprocStateSaveErrors = prometheus.NewDesc(
+		"deeploop_state_save_errors_total", "Total number of failed state saves, summed across concurrent runs.", nil, nil)
+)
+
+// MultiprocCollector implements prometheus.Collector by aggregating the
+// per-process snapshot files a shared MultiprocDirEnv directory accumulates,
+// so a single sidecar `ralph-ml metrics serve` process can expose a combined
+// view across every concurrent `ralph-ml start` invocation without
+// needing them to share a registry in memory (they're separate processes).
+//
+// Counters (CyclesTotal, StateSaveErrors) are summed across snapshots, since
+// each file holds one process's own cumulative total. Gauges (CurrentCycle,
+// BestMetric) are maxed, as the most informative single number when several
+// runs are active at once.
+type MultiprocCollector struct {
+	dir string
+}
+
+// NewMultiprocCollector returns a MultiprocCollector reading snapshots from
+// dir on every Collect call.
+func NewMultiprocCollector(dir string) *MultiprocCollector {
+	return &MultiprocCollector{dir: dir}
+}
+
+// Describe implements prometheus.Collector.
+func (m *MultiprocCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- multiprocCyclesTotal
+	ch <- multiprocCurrentCycle
+	ch <- multiprocBestMetric
+	ch <- multiprocStateSaveErrors
+}
+
+// Collect implements prometheus.Collector, re-reading every snapshot file
+// under dir and aggregating them into a single set of metrics.
+func (m *MultiprocCollector) Collect(ch chan<- prometheus.Metric) {
+	snapshots, err := readMultiprocSnapshots(m.dir)
+	if err != nil || len(snapshots) == 0 {
+		return
+	}
+
+	var cyclesTotal, stateSaveErrors, currentCycle, bestMetric float64
+	for _, snap := range snapshots {
+		cyclesTotal += snap.CyclesTotal
+		stateSaveErrors += snap.StateSaveErrors
+		if snap.CurrentCycle > currentCycle {
+			currentCycle = snap.CurrentCycle
+		}
+		if snap.BestMetric > bestMetric {
+			bestMetric = snap.BestMetric
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(multiprocCyclesTotal, prometheus.CounterValue, cyclesTotal)
+	ch <- prometheus.MustNewConstMetric(multiprocCurrentCycle, prometheus.GaugeValue, currentCycle)
+	ch <- prometheus.MustNewConstMetric(multiprocBestMetric, prometheus.GaugeValue, bestMetric)
+	ch <- prometheus.MustNewConstMetric(multiprocStateSaveErrors, prometheus.CounterValue, stateSaveErrors)
+}
@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func TestWriteMultiprocSnapshotRoundTrips(t *testing.T) {
+	dir := t.TempDir()
+
+	want := Snapshot{CyclesTotal: 4, CurrentCycle: 2, BestMetric: 0.87, StateSaveErrors: 1}
+	if err := WriteMultiprocSnapshot(dir, want); err != nil {
+		t.Fatalf("WriteMultiprocSnapshot failed: %v", err)
+	}
+
+	snapshots, err := readMultiprocSnapshots(dir)
+	if err != nil {
+		t.Fatalf("readMultiprocSnapshots failed: %v", err)
+	}
+	if len(snapshots) != 1 {
+		t.Fatalf("Expected 1 snapshot, got %d", len(snapshots))
+	}
+	if snapshots[0] != want {
+		t.Errorf("Expected snapshot %+v, got %+v", want, snapshots[0])
+	}
+}
+
+func TestMultiprocCollectorAggregatesAcrossProcesses(t *testing.T) {
+	dir := t.TempDir()
+
+	// Simulate two concurrent runs, each writing its own snapshot file.
+	if err := os.WriteFile(filepath.Join(dir, "deeploop-1.json"),
+		[]byte(`{"cycles_total":3,"current_cycle":3,"best_metric":0.80,"state_save_errors_total":1}`), 0644); err != nil {
+		t.Fatalf("Failed to write snapshot: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "deeploop-2.json"),
+		[]byte(`{"cycles_total":5,"current_cycle":7,"best_metric":0.95,"state_save_errors_total":2}`), 0644); err != nil {
+		t.Fatalf("Failed to write snapshot: %v", err)
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(NewMultiprocCollector(dir))
+
+	server := httptest.NewServer(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to scrape metrics endpoint: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read metrics response: %v", err)
+	}
+	out := string(body)
+
+	// Counters sum across processes; gauges report the max.
+	for _, want := range []string{
+		"deeploop_cycles_total 8",
+		"deeploop_current_cycle 7",
+		"deeploop_best_metric 0.95",
+		"deeploop_state_save_errors_total 3",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in scrape output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestMultiprocCollectorEmptyDirYieldsNoMetrics(t *testing.T) {
+	dir := t.TempDir()
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(NewMultiprocCollector(dir))
+
+	metricFamilies, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather failed: %v", err)
+	}
+	if len(metricFamilies) != 0 {
+		t.Errorf("Expected no metric families for an empty directory, got %d", len(metricFamilies))
+	}
+}
+
+func TestCollectorsSnapshotTracksSetters(t *testing.T) {
+	c := NewCollectors()
+	c.IncCyclesTotal()
+	c.IncCyclesTotal()
+	c.SetCurrentCycle(5)
+	c.SetBestMetric(0.42)
+	c.IncStateSaveErrors()
+
+	got := c.Snapshot()
+	want := Snapshot{CyclesTotal: 2, CurrentCycle: 5, BestMetric: 0.42, StateSaveErrors: 1}
+	if got != want {
+		t.Errorf("Expected snapshot %+v, got %+v", want, got)
+	}
+}
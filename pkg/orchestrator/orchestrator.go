@@ -3,28 +3,83 @@ package orchestrator
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/agents"
 	"github.com/matthiaspetry/DeepLoop/cli/pkg/paths"
 )
 
+// defaultGracePeriod is how long Stop waits after each escalation step
+// (SIGINT/CTRL_BREAK, then SIGTERM) before moving on to the next one.
+const defaultGracePeriod = 10 * time.Second
+
+// Event is a single structured line emitted by the Python orchestrator on
+// stdout or stderr, one JSON object per line. Lines that don't parse as an
+// Event fall back to the legacy plain-text streaming path.
+type Event struct {
+	Ts      string                 `json:"ts"`
+	Level   string                 `json:"level"`
+	Cycle   int                    `json:"cycle"`
+	Event   string                 `json:"event"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+// EventHandlers are callbacks dispatched as structured Events are parsed from
+// the orchestrator's output. Any handler may be left nil; unset handlers are
+// simply not called.
+type EventHandlers struct {
+	OnCycleStart func(Event)
+	OnMetric     func(Event)
+	OnDecision   func(Event)
+}
+
+// dispatch routes an Event to the matching registered handler, if any.
+func (h EventHandlers) dispatch(e Event) {
+	switch e.Event {
+	case "cycle_start":
+		if h.OnCycleStart != nil {
+			h.OnCycleStart(e)
+		}
+	case "metric":
+		if h.OnMetric != nil {
+			h.OnMetric(e)
+		}
+	case "decision":
+		if h.OnDecision != nil {
+			h.OnDecision(e)
+		}
+	}
+}
+
 // Orchestrator manages execution of the Python training/orchestration code.
 type Orchestrator struct {
-	pythonPath string
-	timeout    time.Duration
+	pythonPath      string
+	timeout         time.Duration
+	gracePeriod     time.Duration
+	registry        *agents.Registry
+	codeModel       string
+	handlers        EventHandlers
+	resumeFromCycle int
+
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	exited chan struct{}
 }
 
 // NewOrchestrator creates a new orchestrator instance.
 func NewOrchestrator() *Orchestrator {
 	return &Orchestrator{
-		pythonPath: "python", // Default to python, will be detected
-		timeout:    30 * time.Minute,
+		pythonPath:  "python", // Default to python, will be detected
+		timeout:     30 * time.Minute,
+		gracePeriod: defaultGracePeriod,
 	}
 }
 
@@ -38,22 +93,143 @@ func (o *Orchestrator) SetTimeout(timeout time.Duration) {
 	o.timeout = timeout
 }
 
-// Run starts the Python orchestrator with given prompt and config.
-func (o *Orchestrator) Run(ctx context.Context, prompt string, configPath string) error {
-	// Find orchestrator CLI
+// SetGracePeriod sets how long Stop (and a cancelled context) waits after
+// each escalation step of the shutdown sequence before moving to the next.
+func (o *Orchestrator) SetGracePeriod(grace time.Duration) {
+	o.gracePeriod = grace
+}
+
+// Stop triggers the same interrupt -> grace -> terminate -> grace -> kill
+// shutdown sequence used on context cancellation, against whichever process
+// is currently running. It's a no-op if nothing is running. grace overrides
+// the orchestrator's configured grace period for this call.
+func (o *Orchestrator) Stop(grace time.Duration) error {
+	o.mu.Lock()
+	cmd, exited := o.cmd, o.exited
+	o.mu.Unlock()
+	if cmd == nil {
+		return nil
+	}
+	return shutdownProcessGroup(cmd, grace, exited)
+}
+
+// setRunning records the currently running command and its exit signal so
+// Stop (and a cancelled context) can signal and wait on it.
+func (o *Orchestrator) setRunning(cmd *exec.Cmd, exited chan struct{}) {
+	o.mu.Lock()
+	o.cmd, o.exited = cmd, exited
+	o.mu.Unlock()
+}
+
+// clearRunning removes the record of cmd once it has finished, so Stop
+// becomes a no-op again.
+func (o *Orchestrator) clearRunning(cmd *exec.Cmd) {
+	o.mu.Lock()
+	if o.cmd == cmd {
+		o.cmd, o.exited = nil, nil
+	}
+	o.mu.Unlock()
+}
+
+// shutdownProcessGroup escalates through interrupt, terminate, and kill
+// signals to cmd's whole process group, waiting up to grace after each step
+// for exited to close before moving to the next.
+func shutdownProcessGroup(cmd *exec.Cmd, grace time.Duration, exited <-chan struct{}) error {
+	steps := []func(*exec.Cmd) error{interruptProcessGroup, terminateProcessGroup, killProcessGroup}
+	for _, signal := range steps {
+		signal(cmd)
+		select {
+		case <-exited:
+			return nil
+		case <-time.After(grace):
+		}
+	}
+	return nil
+}
+
+// SetEventHandlers registers callbacks for structured JSON events parsed
+// from the orchestrator's stdout/stderr during RunWithStreaming.
+func (o *Orchestrator) SetEventHandlers(handlers EventHandlers) {
+	o.handlers = handlers
+}
+
+// SetResumeFromCycle tells the orchestrator to resume an existing run
+// starting at the given cycle number, instead of starting a fresh run at
+// cycle 0. A cycle number of 0 means "not resuming".
+func (o *Orchestrator) SetResumeFromCycle(cycle int) {
+	o.resumeFromCycle = cycle
+}
+
+// SetAgent configures the plugin registry and the `code_model` name to
+// resolve against it. When the name resolves to an installed plugin, the
+// orchestrator launches that plugin's entrypoint instead of the built-in
+// opencode runner.
+func (o *Orchestrator) SetAgent(registry *agents.Registry, codeModel string) {
+	o.registry = registry
+	o.codeModel = codeModel
+}
+
+// resolveCommand returns the executable, base args, and environment to
+// launch the orchestrator with, preferring a registered plugin for
+// o.codeModel over the hardcoded python + orchestrator_cli.py launch. A nil
+// env means "inherit the default process environment" (exec.Cmd's own
+// behavior when Cmd.Env is left unset).
+func (o *Orchestrator) resolveCommand() (string, []string, []string, error) {
+	if o.registry != nil && o.codeModel != "" {
+		if plugin, ok := o.registry.Resolve(o.codeModel); ok {
+			exe, args := plugin.Command()
+			env, err := plugin.Env()
+			if err != nil {
+				return "", nil, nil, err
+			}
+			return exe, args, env, nil
+		}
+	}
+
 	cliPath := o.findOrchestratorCLI()
 	if cliPath == "" {
-		return fmt.Errorf("could not find orchestrator_cli.py. Is ralph_ml installed?")
+		return "", nil, nil, fmt.Errorf("could not find orchestrator_cli.py. Is ralph_ml installed?")
 	}
+	return o.pythonPath, []string{cliPath}, nil, nil
+}
 
-	// Build Python command
-	args := []string{cliPath, prompt}
+// buildArgs assembles the full argument list for the orchestrator process:
+// base plugin/CLI args, the prompt, and optional --config/--resume-cycle
+// flags.
+func (o *Orchestrator) buildArgs(baseArgs []string, prompt, configPath string) []string {
+	args := append(append([]string{}, baseArgs...), prompt)
 	if configPath != "" {
 		args = append(args, "--config", configPath)
 	}
+	if o.resumeFromCycle > 0 {
+		args = append(args, "--resume-cycle", fmt.Sprintf("%d", o.resumeFromCycle))
+	}
+	return args
+}
+
+// Run starts the Python orchestrator with given prompt and config. The
+// orchestrator's configured timeout bounds the whole call; on cancellation
+// (timeout or parent context) the process group is shut down gracefully
+// rather than killed outright, so child workers get a chance to clean up.
+func (o *Orchestrator) Run(ctx context.Context, prompt string, configPath string) error {
+	exe, baseArgs, env, err := o.resolveCommand()
+	if err != nil {
+		return err
+	}
 
-	// Create command
-	cmd := exec.CommandContext(ctx, o.pythonPath, args...)
+	args := o.buildArgs(baseArgs, prompt, configPath)
+
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	// Create command, detached into its own process group so the whole
+	// tree can be signalled on shutdown instead of just this one process.
+	cmd := exec.Command(exe, args...)
+	setProcAttrs(cmd)
+	cmd.Env = env
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -62,17 +238,25 @@ func (o *Orchestrator) Run(ctx context.Context, prompt string, configPath string
 		return fmt.Errorf("failed to start orchestrator: %w", err)
 	}
 
+	exited := make(chan struct{})
+	o.setRunning(cmd, exited)
+	defer o.clearRunning(cmd)
+
 	// Wait for completion
 	done := make(chan error, 1)
 	go func() {
-		done <- cmd.Wait()
+		err := cmd.Wait()
+		close(exited)
+		done <- err
 	}()
 
-	// Wait for command or timeout
+	// Wait for command or timeout/cancellation
 	select {
 	case <-ctx.Done():
-		// Context cancelled
-		cmd.Process.Kill()
+		shutdownProcessGroup(cmd, o.gracePeriod, exited)
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("orchestrator timed out after %s", o.timeout)
+		}
 		return fmt.Errorf("operation cancelled")
 	case err := <-done:
 		if err != nil {
@@ -84,20 +268,18 @@ func (o *Orchestrator) Run(ctx context.Context, prompt string, configPath string
 
 // RunWithStreaming runs the Python orchestrator with streaming output.
 func (o *Orchestrator) RunWithStreaming(ctx context.Context, prompt string, configPath string, outputWriter io.Writer) error {
-	// Find orchestrator CLI
-	cliPath := o.findOrchestratorCLI()
-	if cliPath == "" {
-		return fmt.Errorf("could not find orchestrator_cli.py. Is ralph_ml installed?")
+	exe, baseArgs, env, err := o.resolveCommand()
+	if err != nil {
+		return err
 	}
 
-	// Build Python command
-	args := []string{cliPath, prompt}
-	if configPath != "" {
-		args = append(args, "--config", configPath)
-	}
+	args := o.buildArgs(baseArgs, prompt, configPath)
 
-	// Create command
-	cmd := exec.CommandContext(ctx, o.pythonPath, args...)
+	// Create command, detached into its own process group so the whole
+	// tree can be signalled on shutdown instead of just this one process.
+	cmd := exec.Command(exe, args...)
+	setProcAttrs(cmd)
+	cmd.Env = env
 
 	// Create pipes for stdout and stderr
 	stdoutPipe, err := cmd.StdoutPipe()
@@ -122,7 +304,7 @@ func (o *Orchestrator) RunWithStreaming(ctx context.Context, prompt string, conf
 	go func() {
 		scanner := bufio.NewScanner(stdoutPipe)
 		for scanner.Scan() {
-			fmt.Fprintln(outputWriter, scanner.Text())
+			o.handleStreamLine(scanner.Text(), "", outputWriter)
 		}
 		done <- scanner.Err()
 	}()
@@ -131,15 +313,21 @@ func (o *Orchestrator) RunWithStreaming(ctx context.Context, prompt string, conf
 	go func() {
 		scanner := bufio.NewScanner(stderrPipe)
 		for scanner.Scan() {
-			fmt.Fprintln(outputWriter, "[STDERR] "+scanner.Text())
+			o.handleStreamLine(scanner.Text(), "[STDERR] ", outputWriter)
 		}
 		done <- scanner.Err()
 	}()
 
 	// Wait for completion
+	exited := make(chan struct{})
+	o.setRunning(cmd, exited)
+	defer o.clearRunning(cmd)
+
 	cmdDone := make(chan error, 1)
 	go func() {
-		cmdDone <- cmd.Wait()
+		err := cmd.Wait()
+		close(exited)
+		cmdDone <- err
 	}()
 
 	// Wait for all streams and command
@@ -155,8 +343,7 @@ func (o *Orchestrator) RunWithStreaming(ctx context.Context, prompt string, conf
 				waitErr = err
 			}
 		case <-ctx.Done():
-			// Context cancelled
-			cmd.Process.Kill()
+			shutdownProcessGroup(cmd, o.gracePeriod, exited)
 			return fmt.Errorf("operation cancelled")
 		}
 	}
@@ -168,6 +355,21 @@ func (o *Orchestrator) RunWithStreaming(ctx context.Context, prompt string, conf
 	return nil
 }
 
+// handleStreamLine processes one line of orchestrator stdout/stderr during
+// RunWithStreaming. Lines that parse as a structured Event are dispatched to
+// the registered handlers; everything else falls back to the legacy
+// plain-text path (with a "[STDERR] " prefix for stderr lines) so scripts
+// that don't emit structured events keep working unchanged.
+func (o *Orchestrator) handleStreamLine(line, textPrefix string, outputWriter io.Writer) {
+	var e Event
+	if err := json.Unmarshal([]byte(line), &e); err == nil && e.Event != "" {
+		o.handlers.dispatch(e)
+		fmt.Fprintln(outputWriter, line)
+		return
+	}
+	fmt.Fprintln(outputWriter, textPrefix+line)
+}
+
 // findOrchestratorCLI finds the orchestrator_cli.py file.
 func (o *Orchestrator) findOrchestratorCLI() string {
 	// Try current directory first
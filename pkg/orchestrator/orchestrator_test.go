@@ -0,0 +1,178 @@
+package orchestrator
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/agents"
+)
+
+func TestBuildArgsBasic(t *testing.T) {
+	o := NewOrchestrator()
+
+	args := o.buildArgs([]string{"cli.py"}, "do the thing", "")
+	want := []string{"cli.py", "do the thing"}
+	if !equalStrings(args, want) {
+		t.Errorf("buildArgs() = %v, want %v", args, want)
+	}
+}
+
+func TestBuildArgsWithConfigAndResume(t *testing.T) {
+	o := NewOrchestrator()
+	o.SetResumeFromCycle(3)
+
+	args := o.buildArgs([]string{"cli.py"}, "do the thing", "config.yaml")
+	want := []string{"cli.py", "do the thing", "--config", "config.yaml", "--resume-cycle", "3"}
+	if !equalStrings(args, want) {
+		t.Errorf("buildArgs() = %v, want %v", args, want)
+	}
+}
+
+func TestResolveCommandDefaultsToPythonCLI(t *testing.T) {
+	o := NewOrchestrator()
+	o.SetPythonPath("python3")
+
+	exe, args, env, err := o.resolveCommand()
+	if err == nil {
+		t.Fatalf("expected an error when orchestrator_cli.py can't be found, got exe=%s args=%v env=%v", exe, args, env)
+	}
+}
+
+func TestResolveCommandPrefersResolvedPlugin(t *testing.T) {
+	registry := registryWithPlugin(t, "claude", agents.Manifest{
+		Name:       "claude",
+		Entrypoint: "run.sh",
+		Args:       []string{"--flag"},
+	})
+
+	o := NewOrchestrator()
+	o.SetAgent(registry, "claude")
+
+	exe, args, _, err := o.resolveCommand()
+	if err != nil {
+		t.Fatalf("resolveCommand failed: %v", err)
+	}
+	if exe == "" || len(args) != 1 || args[0] != "--flag" {
+		t.Errorf("Expected the plugin's command to be resolved, got exe=%s args=%v", exe, args)
+	}
+}
+
+func TestResolveCommandErrorsOnMissingRequiredEnv(t *testing.T) {
+	registry := registryWithPlugin(t, "claude", agents.Manifest{
+		Name:        "claude",
+		Entrypoint:  "run.sh",
+		RequiredEnv: []string{"RALPH_ML_TEST_MISSING_VAR"},
+	})
+
+	o := NewOrchestrator()
+	o.SetAgent(registry, "claude")
+
+	if _, _, _, err := o.resolveCommand(); err == nil {
+		t.Error("Expected an error when the plugin's required env vars aren't set")
+	}
+}
+
+func TestResolveCommandFallsBackToBuiltinWhenCodeModelUnresolved(t *testing.T) {
+	registry := registryWithPlugin(t, "claude", agents.Manifest{Name: "claude", Entrypoint: "run.sh"})
+
+	o := NewOrchestrator()
+	o.SetAgent(registry, "opencode") // not a registered plugin name
+
+	// Falls through to findOrchestratorCLI, which won't find anything in a
+	// test environment, so this should fail the same way the no-agent case
+	// does rather than returning the claude plugin's command.
+	if _, _, _, err := o.resolveCommand(); err == nil {
+		t.Error("Expected an error falling back to the built-in CLI lookup")
+	}
+}
+
+func TestHandleStreamLineDispatchesStructuredEvents(t *testing.T) {
+	var cycleStarts, metrics int
+	o := NewOrchestrator()
+	o.SetEventHandlers(EventHandlers{
+		OnCycleStart: func(Event) { cycleStarts++ },
+		OnMetric:     func(Event) { metrics++ },
+	})
+
+	var buf bytes.Buffer
+	o.handleStreamLine(`{"event":"cycle_start","cycle":1}`, "", &buf)
+	o.handleStreamLine(`{"event":"metric","cycle":1}`, "", &buf)
+
+	if cycleStarts != 1 {
+		t.Errorf("Expected OnCycleStart to be called once, got %d", cycleStarts)
+	}
+	if metrics != 1 {
+		t.Errorf("Expected OnMetric to be called once, got %d", metrics)
+	}
+
+	out := buf.String()
+	if !bytes.Contains([]byte(out), []byte(`"event":"cycle_start"`)) {
+		t.Errorf("Expected the raw event line to still be written to the output writer, got:\n%s", out)
+	}
+}
+
+func TestHandleStreamLineFallsBackToPlainTextForNonEventLines(t *testing.T) {
+	o := NewOrchestrator()
+
+	var buf bytes.Buffer
+	o.handleStreamLine("plain log line", "[STDERR] ", &buf)
+
+	if got, want := buf.String(), "[STDERR] plain log line\n"; got != want {
+		t.Errorf("handleStreamLine output = %q, want %q", got, want)
+	}
+}
+
+func TestHandleStreamLineFallsBackForJSONWithoutEventField(t *testing.T) {
+	o := NewOrchestrator()
+
+	var buf bytes.Buffer
+	o.handleStreamLine(`{"foo":"bar"}`, "", &buf)
+
+	if got, want := buf.String(), "{\"foo\":\"bar\"}\n"; got != want {
+		t.Errorf("handleStreamLine output = %q, want %q", got, want)
+	}
+}
+
+// registryWithPlugin builds an agents.Registry containing a single plugin by
+// writing a manifest into a fake per-user plugins directory and loading it
+// through the normal agents.NewRegistry discovery path (Registry's plugin
+// map is unexported, so this package can't construct one directly).
+func registryWithPlugin(t *testing.T, name string, m agents.Manifest) *agents.Registry {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	pluginDir := filepath.Join(home, ".ralph-ml", "plugins", name)
+	if err := os.MkdirAll(pluginDir, 0755); err != nil {
+		t.Fatalf("failed to create plugin dir: %v", err)
+	}
+	m.Name = name
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("failed to marshal manifest: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(pluginDir, agents.ManifestFileName), data, 0644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	registry, err := agents.NewRegistry()
+	if err != nil {
+		t.Fatalf("NewRegistry failed: %v", err)
+	}
+	return registry
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,27 @@
+//go:build !windows
+
+package orchestrator
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcAttrs configures cmd to run in its own process group so the whole
+// process tree (including any child workers the orchestrator spawns) can be
+// signalled together.
+func setProcAttrs(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// signalProcessGroup delivers sig to every process in cmd's process group.
+func signalProcessGroup(cmd *exec.Cmd, sig syscall.Signal) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return syscall.Kill(-cmd.Process.Pid, sig)
+}
+
+func interruptProcessGroup(cmd *exec.Cmd) error { return signalProcessGroup(cmd, syscall.SIGINT) }
+func terminateProcessGroup(cmd *exec.Cmd) error { return signalProcessGroup(cmd, syscall.SIGTERM) }
+func killProcessGroup(cmd *exec.Cmd) error      { return signalProcessGroup(cmd, syscall.SIGKILL) }
@@ -0,0 +1,55 @@
+//go:build windows
+
+package orchestrator
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+const createNewProcessGroup = 0x00000200
+
+// setProcAttrs configures cmd to run in its own process group so CTRL_BREAK
+// can be delivered to the whole process tree without affecting this parent
+// process.
+func setProcAttrs(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: createNewProcessGroup}
+}
+
+// interruptProcessGroup sends CTRL_BREAK_EVENT to cmd's process group,
+// Windows' closest equivalent to SIGINT for a process group.
+func interruptProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	d, err := syscall.LoadDLL("kernel32.dll")
+	if err != nil {
+		return err
+	}
+	p, err := d.FindProc("GenerateConsoleCtrlEvent")
+	if err != nil {
+		return err
+	}
+	const ctrlBreakEvent = 1
+	r, _, err := p.Call(uintptr(ctrlBreakEvent), uintptr(cmd.Process.Pid))
+	if r == 0 {
+		return err
+	}
+	return nil
+}
+
+// terminateProcessGroup has no graceful SIGTERM equivalent on Windows, so it
+// falls straight through to Kill like killProcessGroup.
+func terminateProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return cmd.Process.Kill()
+}
+
+func killProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return cmd.Process.Kill()
+}
@@ -5,11 +5,18 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/matthiaspetry/DeepLoop/cli/pkg/config"
 )
 
+// cycleTmpSuffix marks a cycle's working directory as not yet finalized.
+// A crash or Ctrl-C mid-cycle leaves a cycle_XXXX.tmp/ directory behind
+// instead of a half-written cycle_XXXX/, so resume can unambiguously tell
+// which cycles are safe to keep.
+const cycleTmpSuffix = ".tmp"
+
 // Paths holds all resolved paths for a Ralph ML Loop run.
 type Paths struct {
 	Workspace string
@@ -66,7 +73,7 @@ func ResolvePaths(cfg *config.Config) (*Paths, error) {
 func (p *Paths) CreateRunDirectory() (string, error) {
 	// Generate run ID based on timestamp
 	runID := time.Now().Format("run_20060102_150405") + "_000"
-	
+
 	runRoot := filepath.Join(p.Runs, runID)
 	p.RunRoot = normalizePath(runRoot)
 
@@ -88,6 +95,132 @@ func (p *Paths) CreateRunDirectory() (string, error) {
 	return normalizePath(runRoot), nil
 }
 
+// CreateCycleDir creates the working directory for a cycle as
+// cycle_XXXX.tmp/ under runRoot/cycles. It is only renamed to its final
+// cycle_XXXX/ name by FinalizeCycleDir, once the cycle has written a
+// .complete marker inside it.
+//
+// Nothing in this tree calls CreateCycleDir/FinalizeCycleDir yet: actual
+// cycle content (metrics.json, analysis.json, etc.) is written by the
+// external Python orchestrator subprocess this CLI launches (see
+// pkg/orchestrator), not by this Go program, and that subprocess's source
+// isn't part of this repo. So the tmp-then-.complete-then-rename
+// atomicity guarantee these two functions provide isn't actually in place
+// for any real run yet — it's here, ready for a caller that does own the
+// write path (in-tree cycle execution, or passing the tmp dir across the
+// subprocess boundary as a CLI flag) to adopt.
+func CreateCycleDir(runRoot string, cycleNum int) (string, error) {
+	tmpDir := GetSessionCycleDir(runRoot, cycleNum) + cycleTmpSuffix
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create cycle directory %s: %w", tmpDir, err)
+	}
+	return normalizePath(tmpDir), nil
+}
+
+// FinalizeCycleDir writes a .complete marker inside tmpDir and renames it
+// from cycle_XXXX.tmp/ to cycle_XXXX/. Call this only after all of the
+// cycle's output has been written to tmpDir, so the rename is the last,
+// atomic step.
+func FinalizeCycleDir(tmpDir string) (string, error) {
+	marker := filepath.Join(tmpDir, ".complete")
+	if err := os.WriteFile(marker, []byte{}, 0644); err != nil {
+		return "", fmt.Errorf("failed to write completion marker: %w", err)
+	}
+
+	finalDir := strings.TrimSuffix(tmpDir, cycleTmpSuffix)
+	if err := os.Rename(tmpDir, finalDir); err != nil {
+		return "", fmt.Errorf("failed to finalize cycle directory %s: %w", tmpDir, err)
+	}
+	return normalizePath(finalDir), nil
+}
+
+// ResumeRun resolves an existing run_... directory by ID for resuming. Any
+// trailing cycle_XXXX.tmp/ left behind by a crash or Ctrl-C is removed, since
+// it was never marked complete. It returns the resumed Paths and the next
+// cycle number to run.
+func (p *Paths) ResumeRun(runID string) (*Paths, int, error) {
+	runRoot := filepath.Join(p.Runs, runID)
+	if _, err := os.Stat(runRoot); err != nil {
+		return nil, 0, fmt.Errorf("run not found: %s", runID)
+	}
+
+	nextCycle, err := cleanIncompleteCycles(runRoot)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resumed := *p
+	resumed.RunRoot = normalizePath(runRoot)
+	return &resumed, nextCycle, nil
+}
+
+// ResumeLatest resolves the run_... directory under Runs with the latest
+// modification time for resuming. It returns an error if no runs exist yet.
+func (p *Paths) ResumeLatest() (*Paths, int, error) {
+	entries, err := os.ReadDir(p.Runs)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read runs directory: %w", err)
+	}
+
+	var latest string
+	var latestModTime time.Time
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), "run_") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if latest == "" || info.ModTime().After(latestModTime) {
+			latest = entry.Name()
+			latestModTime = info.ModTime()
+		}
+	}
+
+	if latest == "" {
+		return nil, 0, fmt.Errorf("no existing runs found in %s", p.Runs)
+	}
+
+	return p.ResumeRun(latest)
+}
+
+// cleanIncompleteCycles removes any cycle_XXXX.tmp/ directories left behind
+// by a crashed or interrupted cycle, and returns the next cycle number to
+// run, one past the highest complete cycle_XXXX/ directory found.
+func cleanIncompleteCycles(runRoot string) (int, error) {
+	cyclesDir := filepath.Join(runRoot, "cycles")
+	entries, err := os.ReadDir(cyclesDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read cycles directory: %w", err)
+	}
+
+	nextCycle := 0
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+		if strings.HasSuffix(name, cycleTmpSuffix) {
+			if err := os.RemoveAll(filepath.Join(cyclesDir, name)); err != nil {
+				return 0, fmt.Errorf("failed to remove incomplete cycle %s: %w", name, err)
+			}
+			continue
+		}
+
+		var cycleNum int
+		if _, err := fmt.Sscanf(name, "cycle_%04d", &cycleNum); err == nil && cycleNum+1 > nextCycle {
+			nextCycle = cycleNum + 1
+		}
+	}
+
+	return nextCycle, nil
+}
+
 // CreateDirectories creates all directories in the Paths struct.
 func (p *Paths) CreateDirectories() error {
 	dirs := []string{
@@ -144,7 +277,7 @@ func (p *Paths) UpdateFromConfig(cfg *config.Config) error {
 func normalizePath(path string) string {
 	// Clean the path to remove any . or .. elements
 	path = filepath.Clean(path)
-	
+
 	// On Windows, paths are already normalized by filepath.Clean
 	// On Unix, ensure we're using forward slashes (Go's default)
 	return path
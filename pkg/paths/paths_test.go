@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/matthiaspetry/DeepLoop/cli/pkg/config"
 )
@@ -88,6 +89,127 @@ func TestCreateRunDirectory(t *testing.T) {
 	}
 }
 
+func TestCreateAndFinalizeCycleDir(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := config.NewDefaultConfig()
+	cfg.Paths.Runs = tmpDir
+
+	p, err := ResolvePaths(cfg)
+	if err != nil {
+		t.Fatalf("Failed to resolve paths: %v", err)
+	}
+
+	runRoot, err := p.CreateRunDirectory()
+	if err != nil {
+		t.Fatalf("Failed to create run directory: %v", err)
+	}
+
+	cycleTmpDir, err := CreateCycleDir(runRoot, 1)
+	if err != nil {
+		t.Fatalf("Failed to create cycle directory: %v", err)
+	}
+	if filepath.Base(cycleTmpDir) != "cycle_0001.tmp" {
+		t.Errorf("Expected cycle_0001.tmp, got '%s'", filepath.Base(cycleTmpDir))
+	}
+
+	finalDir, err := FinalizeCycleDir(cycleTmpDir)
+	if err != nil {
+		t.Fatalf("Failed to finalize cycle directory: %v", err)
+	}
+	if filepath.Base(finalDir) != "cycle_0001" {
+		t.Errorf("Expected cycle_0001, got '%s'", filepath.Base(finalDir))
+	}
+	if _, err := os.Stat(filepath.Join(finalDir, ".complete")); os.IsNotExist(err) {
+		t.Error(".complete marker was not carried over to the finalized cycle directory")
+	}
+	if _, err := os.Stat(cycleTmpDir); !os.IsNotExist(err) {
+		t.Error("cycle_0001.tmp should no longer exist after finalization")
+	}
+}
+
+func TestResumeRunRemovesIncompleteCycle(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := config.NewDefaultConfig()
+	cfg.Paths.Runs = tmpDir
+
+	p, err := ResolvePaths(cfg)
+	if err != nil {
+		t.Fatalf("Failed to resolve paths: %v", err)
+	}
+
+	runRoot, err := p.CreateRunDirectory()
+	if err != nil {
+		t.Fatalf("Failed to create run directory: %v", err)
+	}
+
+	// Cycle 0 completed successfully.
+	cycle0Tmp, err := CreateCycleDir(runRoot, 0)
+	if err != nil {
+		t.Fatalf("Failed to create cycle 0 directory: %v", err)
+	}
+	if _, err := FinalizeCycleDir(cycle0Tmp); err != nil {
+		t.Fatalf("Failed to finalize cycle 0 directory: %v", err)
+	}
+
+	// Cycle 1 was interrupted before it could be finalized.
+	if _, err := CreateCycleDir(runRoot, 1); err != nil {
+		t.Fatalf("Failed to create cycle 1 directory: %v", err)
+	}
+
+	resumed, nextCycle, err := p.ResumeRun(filepath.Base(runRoot))
+	if err != nil {
+		t.Fatalf("Failed to resume run: %v", err)
+	}
+	if nextCycle != 1 {
+		t.Errorf("Expected next cycle 1, got %d", nextCycle)
+	}
+	if resumed.RunRoot != normalizePath(runRoot) {
+		t.Errorf("Expected resumed run root '%s', got '%s'", runRoot, resumed.RunRoot)
+	}
+
+	incompleteDir := GetSessionCycleDir(runRoot, 1) + cycleTmpSuffix
+	if _, err := os.Stat(incompleteDir); !os.IsNotExist(err) {
+		t.Error("incomplete cycle_0001.tmp should have been removed by ResumeRun")
+	}
+}
+
+func TestResumeLatestPicksMostRecentRun(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := config.NewDefaultConfig()
+	cfg.Paths.Runs = tmpDir
+
+	p, err := ResolvePaths(cfg)
+	if err != nil {
+		t.Fatalf("Failed to resolve paths: %v", err)
+	}
+
+	olderRoot := filepath.Join(tmpDir, "run_20260101_000000_000")
+	if err := os.MkdirAll(filepath.Join(olderRoot, "cycles"), 0755); err != nil {
+		t.Fatalf("Failed to create older run: %v", err)
+	}
+
+	newerRoot := filepath.Join(tmpDir, "run_20260102_000000_000")
+	if err := os.MkdirAll(filepath.Join(newerRoot, "cycles"), 0755); err != nil {
+		t.Fatalf("Failed to create newer run: %v", err)
+	}
+
+	newerTime := time.Now().Add(time.Minute)
+	if err := os.Chtimes(newerRoot, newerTime, newerTime); err != nil {
+		t.Fatalf("Failed to set newer run mtime: %v", err)
+	}
+
+	resumed, _, err := p.ResumeLatest()
+	if err != nil {
+		t.Fatalf("Failed to resume latest run: %v", err)
+	}
+	if resumed.RunRoot != normalizePath(newerRoot) {
+		t.Errorf("Expected to resume '%s', got '%s'", newerRoot, resumed.RunRoot)
+	}
+}
+
 func TestGetCycleDir(t *testing.T) {
 	runsDir := "/tmp/runs"
 	cycleNum := 5
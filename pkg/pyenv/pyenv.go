@@ -0,0 +1,139 @@
+// Package pyenv verifies that a Python interpreter is new enough and has
+// the packages Ralph ML Loop needs importable, before the orchestrator is
+// launched against it. This turns "script started but crashed on import"
+// failures, previously only visible via a [STDERR] line, into an
+// actionable check that runs up front.
+package pyenv
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/paths"
+)
+
+// MinPythonVersion is the minimum Python version Ralph ML Loop supports.
+var MinPythonVersion = [2]int{3, 9}
+
+// BaseRequiredModules must be importable regardless of framework.
+var BaseRequiredModules = []string{"ralph_ml"}
+
+// FrameworkModules maps a project framework to its required Python package.
+var FrameworkModules = map[string]string{
+	"pytorch":    "torch",
+	"tensorflow": "tensorflow",
+	"jax":        "jax",
+}
+
+// RequiredModules returns the full manifest of importable modules for the
+// given framework: the base ralph_ml runtime plus the framework's package,
+// if recognized.
+func RequiredModules(framework string) []string {
+	modules := append([]string{}, BaseRequiredModules...)
+	if mod, ok := FrameworkModules[framework]; ok {
+		modules = append(modules, mod)
+	}
+	return modules
+}
+
+// CheckResult is the outcome of CheckEnvironment.
+type CheckResult struct {
+	PythonPath      string
+	PythonVersion   string
+	MeetsMinVersion bool
+	MissingModules  []string
+}
+
+// OK reports whether the environment passed every check.
+func (r *CheckResult) OK() bool {
+	return r.MeetsMinVersion && len(r.MissingModules) == 0
+}
+
+var versionPattern = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// CheckEnvironment runs `python --version` and an import check for each
+// required module. It returns an error only if the interpreter itself
+// couldn't be run; version and missing-module problems are reported
+// through the returned CheckResult instead, since doctor and start want to
+// print all of them together rather than stopping at the first one.
+func CheckEnvironment(pythonPath string, requiredModules []string) (*CheckResult, error) {
+	result := &CheckResult{PythonPath: pythonPath}
+
+	out, err := exec.Command(pythonPath, "--version").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("python not found: %w", err)
+	}
+	result.PythonVersion = strings.TrimSpace(string(out))
+	result.MeetsMinVersion = meetsMinVersion(result.PythonVersion)
+
+	for _, module := range requiredModules {
+		if err := exec.Command(pythonPath, "-c", fmt.Sprintf("import %s", module)).Run(); err != nil {
+			result.MissingModules = append(result.MissingModules, module)
+		}
+	}
+
+	return result, nil
+}
+
+// meetsMinVersion reports whether a `python --version` output (e.g.
+// "Python 3.11.4") satisfies MinPythonVersion.
+func meetsMinVersion(versionOutput string) bool {
+	match := versionPattern.FindStringSubmatch(versionOutput)
+	if match == nil {
+		return false
+	}
+
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	if major != MinPythonVersion[0] {
+		return major > MinPythonVersion[0]
+	}
+	return minor >= MinPythonVersion[1]
+}
+
+// VenvPythonPath returns the path to the python interpreter inside venvDir,
+// for launching or checking a virtual environment EnsureVenv provisioned.
+func VenvPythonPath(venvDir string) string {
+	if paths.IsWindows() {
+		return filepath.Join(venvDir, "Scripts", "python.exe")
+	}
+	return filepath.Join(venvDir, "bin", "python")
+}
+
+// venvPipPath returns the path to the pip executable inside venvDir.
+func venvPipPath(venvDir string) string {
+	if paths.IsWindows() {
+		return filepath.Join(venvDir, "Scripts", "pip.exe")
+	}
+	return filepath.Join(venvDir, "bin", "pip")
+}
+
+// EnsureVenv creates venvDir with `python -m venv` if it doesn't already
+// exist, then installs dependencies from lockfile with pip, if lockfile is
+// non-empty and exists. It is a no-op if venvDir already exists.
+func EnsureVenv(pythonPath, venvDir, lockfile string) error {
+	if _, err := os.Stat(venvDir); err == nil {
+		return nil
+	}
+
+	if err := exec.Command(pythonPath, "-m", "venv", venvDir).Run(); err != nil {
+		return fmt.Errorf("failed to create virtual environment at %s: %w", venvDir, err)
+	}
+
+	if lockfile == "" {
+		return nil
+	}
+	if _, err := os.Stat(lockfile); err != nil {
+		return nil
+	}
+
+	if err := exec.Command(venvPipPath(venvDir), "install", "-r", lockfile).Run(); err != nil {
+		return fmt.Errorf("failed to install dependencies from %s: %w", lockfile, err)
+	}
+	return nil
+}
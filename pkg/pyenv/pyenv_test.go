@@ -0,0 +1,76 @@
+package pyenv
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/matthiaspetry/DeepLoop/cli/pkg/paths"
+)
+
+func TestMeetsMinVersion(t *testing.T) {
+	tests := map[string]bool{
+		"Python 3.9.0":  true,
+		"Python 3.12.1": true,
+		"Python 3.8.10": false,
+		"Python 2.7.18": false,
+		"Python 4.0.0":  true,
+		"not a version": false,
+	}
+
+	for version, want := range tests {
+		if got := meetsMinVersion(version); got != want {
+			t.Errorf("meetsMinVersion(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestRequiredModules(t *testing.T) {
+	tests := map[string][]string{
+		"pytorch":    {"ralph_ml", "torch"},
+		"tensorflow": {"ralph_ml", "tensorflow"},
+		"jax":        {"ralph_ml", "jax"},
+		"unknown":    {"ralph_ml"},
+		"":           {"ralph_ml"},
+	}
+
+	for framework, want := range tests {
+		got := RequiredModules(framework)
+		if len(got) != len(want) {
+			t.Fatalf("RequiredModules(%q) = %v, want %v", framework, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("RequiredModules(%q) = %v, want %v", framework, got, want)
+			}
+		}
+	}
+}
+
+func TestVenvPythonPath(t *testing.T) {
+	venvDir := filepath.Join("runs", "abc", ".venv")
+	want := filepath.Join(venvDir, "bin", "python")
+	if paths.IsWindows() {
+		want = filepath.Join(venvDir, "Scripts", "python.exe")
+	}
+
+	if got := VenvPythonPath(venvDir); got != want {
+		t.Errorf("VenvPythonPath(%q) = %q, want %q", venvDir, got, want)
+	}
+}
+
+func TestCheckResultOK(t *testing.T) {
+	ok := &CheckResult{MeetsMinVersion: true}
+	if !ok.OK() {
+		t.Error("Expected OK() to be true with no missing modules")
+	}
+
+	missingModule := &CheckResult{MeetsMinVersion: true, MissingModules: []string{"torch"}}
+	if missingModule.OK() {
+		t.Error("Expected OK() to be false with a missing module")
+	}
+
+	oldVersion := &CheckResult{MeetsMinVersion: false}
+	if oldVersion.OK() {
+		t.Error("Expected OK() to be false when version requirement isn't met")
+	}
+}
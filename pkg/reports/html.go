@@ -0,0 +1,124 @@
+package reports
+
+import (
+	"fmt"
+	"html"
+	"io"
+)
+
+// HTMLRenderer renders cycles as a self-contained HTML report with an
+// embedded SVG chart of the first numeric metric found across cycles.
+type HTMLRenderer struct{}
+
+// Render writes an HTML report for cycles to w.
+func (HTMLRenderer) Render(cycles []CycleData, w io.Writer) error {
+	fmt.Fprintln(w, "<!DOCTYPE html>")
+	fmt.Fprintln(w, "<html><head><meta charset=\"utf-8\"><title>Ralph ML Loop - Final Report</title></head><body>")
+	fmt.Fprintln(w, "<h1>Ralph ML Loop - Final Report</h1>")
+	fmt.Fprintf(w, "<p>Total cycles: %d</p>\n", len(cycles))
+
+	if metricName, series := primaryMetricSeries(cycles); metricName != "" {
+		fmt.Fprintf(w, "<h2>%s over cycles</h2>\n", html.EscapeString(metricName))
+		fmt.Fprintln(w, renderSparkline(series))
+	}
+
+	fmt.Fprintln(w, "<h2>Cycle Results</h2>")
+	for _, c := range cycles {
+		fmt.Fprintf(w, "<h3>%s</h3>\n", html.EscapeString(c.Name))
+		if c.Err != nil {
+			fmt.Fprintf(w, "<p><em>Could not load cycle data: %s</em></p>\n", html.EscapeString(c.Err.Error()))
+			continue
+		}
+
+		fmt.Fprintln(w, "<ul>")
+		for _, key := range sortedKeys(c.Result) {
+			fmt.Fprintf(w, "<li>%s: %s</li>\n", html.EscapeString(key), html.EscapeString(fmt.Sprint(c.Result[key])))
+		}
+		fmt.Fprintln(w, "</ul>")
+
+		if c.Summary != "" {
+			fmt.Fprintf(w, "<p><strong>Summary:</strong> %s</p>\n", html.EscapeString(c.Summary))
+		}
+		if c.Decision.Action != "" {
+			fmt.Fprintf(w, "<p><strong>Decision:</strong> %s", html.EscapeString(c.Decision.Action))
+			if c.Decision.Rationale != "" {
+				fmt.Fprintf(w, " &mdash; <em>%s</em>", html.EscapeString(c.Decision.Rationale))
+			}
+			fmt.Fprintln(w, "</p>")
+		}
+	}
+
+	fmt.Fprintln(w, "</body></html>")
+	return nil
+}
+
+// primaryMetricSeries picks the first numeric result key (in sorted key
+// order, across cycles) and returns its value per cycle (0 where absent),
+// for charting.
+func primaryMetricSeries(cycles []CycleData) (string, []float64) {
+	var metricName string
+	for _, c := range cycles {
+		for _, key := range sortedKeys(c.Result) {
+			if _, ok := c.Result[key].(float64); ok {
+				metricName = key
+				break
+			}
+		}
+		if metricName != "" {
+			break
+		}
+	}
+	if metricName == "" {
+		return "", nil
+	}
+
+	series := make([]float64, len(cycles))
+	for i, c := range cycles {
+		if v, ok := c.Result[metricName].(float64); ok {
+			series[i] = v
+		}
+	}
+	return metricName, series
+}
+
+// renderSparkline draws a minimal embedded SVG line chart of series, with
+// no external JS/CSS dependencies so the report stays self-contained.
+func renderSparkline(series []float64) string {
+	if len(series) == 0 {
+		return ""
+	}
+
+	const width, height, padding = 600.0, 120.0, 10.0
+
+	min, max := series[0], series[0]
+	for _, v := range series {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	if max == min {
+		max = min + 1
+	}
+
+	steps := len(series) - 1
+	if steps < 1 {
+		steps = 1
+	}
+	step := (width - 2*padding) / float64(steps)
+
+	points := ""
+	for i, v := range series {
+		x := padding + float64(i)*step
+		y := height - padding - ((v-min)/(max-min))*(height-2*padding)
+		points += fmt.Sprintf("%.2f,%.2f ", x, y)
+	}
+
+	return fmt.Sprintf(
+		`<svg width="%.0f" height="%.0f" xmlns="http://www.w3.org/2000/svg">`+
+			`<polyline fill="none" stroke="#2563eb" stroke-width="2" points="%s"/>`+
+			`</svg>`,
+		width, height, points)
+}
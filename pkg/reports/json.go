@@ -0,0 +1,49 @@
+package reports
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONRenderer renders cycles as a single JSON array, for machine
+// consumption by downstream tooling.
+type JSONRenderer struct{}
+
+type jsonDecision struct {
+	Action    string `json:"action,omitempty"`
+	Rationale string `json:"rationale,omitempty"`
+}
+
+type jsonCycle struct {
+	Name     string                 `json:"name"`
+	Number   int                    `json:"number"`
+	Result   map[string]interface{} `json:"result,omitempty"`
+	Summary  string                 `json:"summary,omitempty"`
+	Decision jsonDecision           `json:"decision,omitempty"`
+	Error    string                 `json:"error,omitempty"`
+}
+
+// Render writes cycles as a JSON array to w.
+func (JSONRenderer) Render(cycles []CycleData, w io.Writer) error {
+	out := make([]jsonCycle, len(cycles))
+	for i, c := range cycles {
+		jc := jsonCycle{
+			Name:    c.Name,
+			Number:  c.Number,
+			Result:  c.Result,
+			Summary: c.Summary,
+			Decision: jsonDecision{
+				Action:    c.Decision.Action,
+				Rationale: c.Decision.Rationale,
+			},
+		}
+		if c.Err != nil {
+			jc.Error = c.Err.Error()
+		}
+		out[i] = jc
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,57 @@
+package reports
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// JUnitRenderer renders cycles as a JUnit XML test suite, so CI systems can
+// surface a cycle whose metrics couldn't be loaded as a failed test case.
+type JUnitRenderer struct{}
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name    string        `xml:"name,attr"`
+	Failure *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+// Render writes cycles as a JUnit XML test suite to w, with one test case
+// per cycle.
+func (JUnitRenderer) Render(cycles []CycleData, w io.Writer) error {
+	suite := junitTestSuite{
+		Name:  "ralph-ml-cycles",
+		Tests: len(cycles),
+	}
+
+	for _, c := range cycles {
+		tc := junitTestCase{Name: c.Name}
+		if c.Err != nil {
+			suite.Failures++
+			tc.Failure = &junitFailure{
+				Message: "failed to load cycle metrics",
+				Content: c.Err.Error(),
+			}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(suite)
+}
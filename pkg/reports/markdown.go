@@ -0,0 +1,58 @@
+package reports
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// MarkdownRenderer renders cycles as a human-readable markdown summary,
+// matching the report format ralph-ml has always produced.
+type MarkdownRenderer struct{}
+
+// Render writes a markdown report for cycles to w.
+func (MarkdownRenderer) Render(cycles []CycleData, w io.Writer) error {
+	fmt.Fprintln(w, "# Ralph ML Loop - Final Report")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "**Generated:** %s\n", time.Now().Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(w, "**Total cycles:** %d\n", len(cycles))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "## Cycle Results")
+	fmt.Fprintln(w)
+
+	for _, c := range cycles {
+		fmt.Fprintf(w, "### %s\n\n", c.Name)
+
+		if c.Err != nil {
+			fmt.Fprintf(w, "_Could not load cycle data: %v_\n\n", c.Err)
+			continue
+		}
+
+		fmt.Fprintln(w, "**Metrics:**")
+		fmt.Fprintf(w, "- Cycle: %d\n", c.Number)
+		if len(c.Result) > 0 {
+			fmt.Fprintln(w, "- Results:")
+			for _, key := range sortedKeys(c.Result) {
+				fmt.Fprintf(w, "  - %s: %v\n", key, c.Result[key])
+			}
+		}
+
+		if c.Summary != "" {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "**Summary:**")
+			fmt.Fprintln(w, c.Summary)
+		}
+
+		if c.Decision.Action != "" {
+			fmt.Fprintln(w)
+			fmt.Fprintf(w, "**Decision:** %s\n", c.Decision.Action)
+			if c.Decision.Rationale != "" {
+				fmt.Fprintf(w, "_%s_\n", c.Decision.Rationale)
+			}
+		}
+
+		fmt.Fprintln(w)
+	}
+
+	return nil
+}
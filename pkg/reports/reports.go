@@ -0,0 +1,131 @@
+// Package reports loads per-cycle run data into a strongly typed CycleData
+// and renders it via pluggable Renderer implementations (markdown, HTML,
+// JSON, JUnit), so every output format shares the same parsing and metric
+// extraction instead of each re-probing raw JSON.
+package reports
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Decision is the orchestrator's recorded action and rationale for a cycle,
+// read from analysis.json.
+type Decision struct {
+	Action    string
+	Rationale string
+}
+
+// CycleData is the strongly typed view of a single cycle's on-disk output
+// (metrics.json + analysis.json).
+type CycleData struct {
+	Name     string
+	Number   int
+	Result   map[string]interface{}
+	Summary  string
+	Decision Decision
+	Err      error // set when metrics.json couldn't be read or parsed
+}
+
+// LoadCycleData reads metrics.json and analysis.json from a cycle
+// directory into a CycleData. A missing or unparseable metrics.json is
+// recorded in Err rather than returned as an error, so the cycle can still
+// be surfaced by renderers (e.g. as a failed JUnit test case).
+func LoadCycleData(cycleDir string) CycleData {
+	cd := CycleData{Name: filepath.Base(cycleDir)}
+
+	data, err := os.ReadFile(filepath.Join(cycleDir, "metrics.json"))
+	if err != nil {
+		cd.Err = fmt.Errorf("failed to read metrics.json: %w", err)
+	} else {
+		var metrics map[string]interface{}
+		if err := json.Unmarshal(data, &metrics); err != nil {
+			cd.Err = fmt.Errorf("failed to parse metrics.json: %w", err)
+		} else {
+			if cycleNum, ok := metrics["cycle"].(float64); ok {
+				cd.Number = int(cycleNum)
+			}
+			if result, ok := metrics["result"].(map[string]interface{}); ok {
+				cd.Result = result
+			}
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(cycleDir, "analysis.json")); err == nil {
+		var analysis map[string]interface{}
+		if json.Unmarshal(data, &analysis) == nil {
+			if summary, ok := analysis["summary"].(string); ok {
+				cd.Summary = summary
+			}
+			if decision, ok := analysis["decision"].(map[string]interface{}); ok {
+				if action, ok := decision["action"].(string); ok {
+					cd.Decision.Action = action
+				}
+				if rationale, ok := decision["rationale"].(string); ok {
+					cd.Decision.Rationale = rationale
+				}
+			}
+		}
+	}
+
+	return cd
+}
+
+// LoadCycles loads CycleData for every cycle directory, preserving order.
+func LoadCycles(cycleDirs []string) []CycleData {
+	cycles := make([]CycleData, len(cycleDirs))
+	for i, dir := range cycleDirs {
+		cycles[i] = LoadCycleData(dir)
+	}
+	return cycles
+}
+
+// Renderer formats a set of cycles into a report written to w.
+type Renderer interface {
+	Render(cycles []CycleData, w io.Writer) error
+}
+
+// RendererFor returns the Renderer for a named format.
+func RendererFor(format string) (Renderer, error) {
+	switch format {
+	case "", "markdown", "md":
+		return MarkdownRenderer{}, nil
+	case "html":
+		return HTMLRenderer{}, nil
+	case "json":
+		return JSONRenderer{}, nil
+	case "junit":
+		return JUnitRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported report format: %s", format)
+	}
+}
+
+// Ext returns the conventional file extension for a report format.
+func Ext(format string) string {
+	switch format {
+	case "html":
+		return "html"
+	case "json":
+		return "json"
+	case "junit":
+		return "xml"
+	default:
+		return "md"
+	}
+}
+
+// sortedKeys returns a map's keys in sorted order, so renderers produce
+// stable, diffable output instead of Go's randomized map iteration order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,142 @@
+package reports
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeCycleFiles(t *testing.T, cycleDir string, metrics, analysis map[string]interface{}) {
+	t.Helper()
+	if err := os.MkdirAll(cycleDir, 0755); err != nil {
+		t.Fatalf("Failed to create cycle dir: %v", err)
+	}
+	if metrics != nil {
+		data, _ := json.Marshal(metrics)
+		if err := os.WriteFile(filepath.Join(cycleDir, "metrics.json"), data, 0644); err != nil {
+			t.Fatalf("Failed to write metrics.json: %v", err)
+		}
+	}
+	if analysis != nil {
+		data, _ := json.Marshal(analysis)
+		if err := os.WriteFile(filepath.Join(cycleDir, "analysis.json"), data, 0644); err != nil {
+			t.Fatalf("Failed to write analysis.json: %v", err)
+		}
+	}
+}
+
+func TestLoadCycleData(t *testing.T) {
+	tmpDir := t.TempDir()
+	cycleDir := filepath.Join(tmpDir, "cycle_0001")
+
+	writeCycleFiles(t, cycleDir,
+		map[string]interface{}{"cycle": 1, "result": map[string]interface{}{"accuracy": 0.9}},
+		map[string]interface{}{"summary": "good run", "decision": map[string]interface{}{"action": "continue", "rationale": "improving"}},
+	)
+
+	cd := LoadCycleData(cycleDir)
+	if cd.Err != nil {
+		t.Fatalf("Expected no error, got %v", cd.Err)
+	}
+	if cd.Number != 1 {
+		t.Errorf("Expected cycle number 1, got %d", cd.Number)
+	}
+	if cd.Result["accuracy"] != 0.9 {
+		t.Errorf("Expected accuracy 0.9, got %v", cd.Result["accuracy"])
+	}
+	if cd.Summary != "good run" {
+		t.Errorf("Expected summary 'good run', got %q", cd.Summary)
+	}
+	if cd.Decision.Action != "continue" || cd.Decision.Rationale != "improving" {
+		t.Errorf("Unexpected decision: %+v", cd.Decision)
+	}
+}
+
+func TestLoadCycleDataMissingMetrics(t *testing.T) {
+	tmpDir := t.TempDir()
+	cycleDir := filepath.Join(tmpDir, "cycle_0002")
+	if err := os.MkdirAll(cycleDir, 0755); err != nil {
+		t.Fatalf("Failed to create cycle dir: %v", err)
+	}
+
+	cd := LoadCycleData(cycleDir)
+	if cd.Err == nil {
+		t.Error("Expected an error when metrics.json is missing")
+	}
+}
+
+func TestRendererForAndExt(t *testing.T) {
+	tests := map[string]string{
+		"markdown": "md",
+		"":         "md",
+		"html":     "html",
+		"json":     "json",
+		"junit":    "xml",
+	}
+
+	for format, wantExt := range tests {
+		renderer, err := RendererFor(format)
+		if err != nil {
+			t.Errorf("RendererFor(%q) returned error: %v", format, err)
+		}
+		if renderer == nil {
+			t.Errorf("RendererFor(%q) returned nil renderer", format)
+		}
+		if got := Ext(format); got != wantExt {
+			t.Errorf("Ext(%q) = %q, want %q", format, got, wantExt)
+		}
+	}
+
+	if _, err := RendererFor("pdf"); err == nil {
+		t.Error("Expected an error for unsupported format 'pdf'")
+	}
+}
+
+func TestHTMLRendererEscapesResultValues(t *testing.T) {
+	cycles := []CycleData{
+		{Name: "cycle_0000", Result: map[string]interface{}{"note": "<script>alert(1)</script>"}},
+	}
+
+	var buf bytes.Buffer
+	if err := (HTMLRenderer{}).Render(cycles, &buf); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "<script>") {
+		t.Errorf("Expected the result value to be HTML-escaped, got:\n%s", out)
+	}
+	if !strings.Contains(out, "&lt;script&gt;") {
+		t.Errorf("Expected an escaped <script> tag in output, got:\n%s", out)
+	}
+}
+
+func TestRenderersProduceOutput(t *testing.T) {
+	cycles := []CycleData{
+		{Name: "cycle_0000", Number: 0, Result: map[string]interface{}{"accuracy": 0.8}, Summary: "first pass"},
+		{Name: "cycle_0001", Err: os.ErrNotExist},
+	}
+
+	for _, format := range []string{"markdown", "html", "json", "junit"} {
+		renderer, err := RendererFor(format)
+		if err != nil {
+			t.Fatalf("RendererFor(%q) failed: %v", format, err)
+		}
+
+		var buf bytes.Buffer
+		if err := renderer.Render(cycles, &buf); err != nil {
+			t.Fatalf("%s renderer.Render failed: %v", format, err)
+		}
+
+		out := buf.String()
+		if !strings.Contains(out, "cycle_0000") {
+			t.Errorf("%s output missing cycle_0000: %s", format, out)
+		}
+		if !strings.Contains(out, "cycle_0001") {
+			t.Errorf("%s output missing cycle_0001: %s", format, out)
+		}
+	}
+}
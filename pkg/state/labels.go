@@ -0,0 +1,40 @@
+package state
+
+// Labels returns the run metadata labels previously persisted into s.Config
+// by WithLabels, if any were set.
+func (s *State) Labels() map[string]string {
+	if s.Config == nil {
+		return nil
+	}
+	raw, ok := s.Config["labels"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	labels := make(map[string]string, len(raw))
+	for k, v := range raw {
+		if s, ok := v.(string); ok {
+			labels[k] = s
+		}
+	}
+	return labels
+}
+
+// WithLabels returns cfg (creating it if nil) with the given run metadata
+// labels set, ready to be persisted into State.Config on start. A nil or
+// empty labels map leaves cfg unchanged.
+func WithLabels(cfg map[string]interface{}, labels map[string]string) map[string]interface{} {
+	if len(labels) == 0 {
+		return cfg
+	}
+	if cfg == nil {
+		cfg = map[string]interface{}{}
+	}
+
+	raw := make(map[string]interface{}, len(labels))
+	for k, v := range labels {
+		raw[k] = v
+	}
+	cfg["labels"] = raw
+	return cfg
+}
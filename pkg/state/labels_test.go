@@ -0,0 +1,54 @@
+package state
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestWithLabelsAndLabels(t *testing.T) {
+	cfg := WithLabels(nil, map[string]string{"team": "ml-platform", "env": "staging"})
+
+	s := &State{Config: cfg}
+	got := s.Labels()
+	want := map[string]string{"team": "ml-platform", "env": "staging"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected labels %v, got %v", want, got)
+	}
+}
+
+func TestLabelsMissing(t *testing.T) {
+	s := &State{}
+	if labels := s.Labels(); labels != nil {
+		t.Errorf("Expected nil labels on empty state, got %v", labels)
+	}
+}
+
+func TestWithLabelsEmptyLeavesConfigUnchanged(t *testing.T) {
+	if cfg := WithLabels(nil, nil); cfg != nil {
+		t.Errorf("Expected WithLabels(nil, nil) to return nil, got %v", cfg)
+	}
+}
+
+func TestLabelsRoundTripsThroughJSON(t *testing.T) {
+	cfg := WithLabels(nil, map[string]string{"team": "ml-platform"})
+	s := &State{Config: cfg}
+
+	// Simulate a Store load, where json.Unmarshal decodes the nested labels
+	// object back into map[string]interface{}, not map[string]string.
+	raw, err := json.Marshal(s.Config)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var reloadedConfig map[string]interface{}
+	if err := json.Unmarshal(raw, &reloadedConfig); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	reloaded := &State{Config: reloadedConfig}
+	got := reloaded.Labels()
+	want := map[string]string{"team": "ml-platform"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected labels %v after round-trip, got %v", want, got)
+	}
+}
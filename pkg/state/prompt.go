@@ -0,0 +1,40 @@
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HashPrompt returns a stable hex-encoded SHA-256 hash of prompt, used to
+// detect a --prompt passed to resume that doesn't match the one a run was
+// originally started with.
+func HashPrompt(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+// StoredPrompt returns the prompt and its hash previously persisted into
+// s.Config by WithPrompt, if present.
+func (s *State) StoredPrompt() (prompt string, hash string, ok bool) {
+	if s.Config == nil {
+		return "", "", false
+	}
+	p, pOK := s.Config["prompt"].(string)
+	h, hOK := s.Config["prompt_hash"].(string)
+	if !pOK || !hOK {
+		return "", "", false
+	}
+	return p, h, true
+}
+
+// WithPrompt returns cfg (creating it if nil) with the prompt and its hash
+// set, ready to be persisted into State.Config on start so a later resume
+// doesn't require --prompt to be passed again.
+func WithPrompt(cfg map[string]interface{}, prompt string) map[string]interface{} {
+	if cfg == nil {
+		cfg = map[string]interface{}{}
+	}
+	cfg["prompt"] = prompt
+	cfg["prompt_hash"] = HashPrompt(prompt)
+	return cfg
+}
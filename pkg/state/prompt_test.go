@@ -0,0 +1,35 @@
+package state
+
+import "testing"
+
+func TestWithPromptAndStoredPrompt(t *testing.T) {
+	cfg := WithPrompt(nil, "build a classifier")
+
+	s := &State{Config: cfg}
+	prompt, hash, ok := s.StoredPrompt()
+	if !ok {
+		t.Fatal("Expected StoredPrompt to find a stored prompt")
+	}
+	if prompt != "build a classifier" {
+		t.Errorf("Expected prompt 'build a classifier', got %q", prompt)
+	}
+	if hash != HashPrompt("build a classifier") {
+		t.Errorf("Expected hash to match HashPrompt output, got %q", hash)
+	}
+}
+
+func TestStoredPromptMissing(t *testing.T) {
+	s := &State{}
+	if _, _, ok := s.StoredPrompt(); ok {
+		t.Error("Expected StoredPrompt to report not found on empty state")
+	}
+}
+
+func TestHashPromptDiffersOnContentChange(t *testing.T) {
+	if HashPrompt("a") == HashPrompt("b") {
+		t.Error("Expected different prompts to hash differently")
+	}
+	if HashPrompt("a") != HashPrompt("a") {
+		t.Error("Expected HashPrompt to be deterministic")
+	}
+}
@@ -0,0 +1,57 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// StateProvider abstracts where run state lives, so start/resume/the TUI can
+// target a local file, a shared database, or a remote store without
+// changing their call sites. Load/Save operate on a single run's state;
+// List enumerates runs visible to this provider; Watch streams state
+// snapshots as they change until ctx is cancelled.
+type StateProvider interface {
+	Load(ctx context.Context) (*State, error)
+	Save(ctx context.Context, state *State) error
+	List(ctx context.Context) ([]RunInfo, error)
+	Watch(ctx context.Context) (<-chan *State, error)
+}
+
+// NewProvider resolves a URL-style --state flag value to a StateProvider.
+// Recognized schemes: file:// (or a bare path, for backward compatibility
+// with the plain paths LoadState/SaveState always accepted), sqlite://, and
+// s3:// (plus http(s):// for a generic REST-backed store).
+func NewProvider(rawURL string) (StateProvider, error) {
+	scheme, rest, err := splitSchemeAndPath(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch scheme {
+	case "", "file":
+		return newFSProvider(rest), nil
+	case "sqlite":
+		return newSQLiteProvider(rest), nil
+	case "s3":
+		return newS3Provider(rest), nil
+	case "http", "https":
+		return newHTTPProvider(rawURL), nil
+	default:
+		return nil, fmt.Errorf("unsupported state backend scheme %q (expected file://, sqlite://, s3://, or http(s)://)", scheme)
+	}
+}
+
+// splitSchemeAndPath pulls the scheme off a URL-style flag value, returning
+// "" for a bare path so it can be handled the same as file://.
+func splitSchemeAndPath(rawURL string) (scheme, rest string, err error) {
+	if !strings.Contains(rawURL, "://") {
+		return "", rawURL, nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid state URL %q: %w", rawURL, err)
+	}
+	return u.Scheme, u.Host + u.Path, nil
+}
@@ -0,0 +1,77 @@
+package state
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// watchPollInterval is how often fsProvider.Watch checks the state file's
+// mtime for changes. There's no filesystem-event dependency vendored in
+// this tree, so polling is the simplest thing that works everywhere.
+const watchPollInterval = 500 * time.Millisecond
+
+// fsProvider is the StateProvider backed by a single local JSON file via
+// Store, preserving the original LoadState/SaveState behavior.
+type fsProvider struct {
+	path string
+}
+
+func newFSProvider(path string) *fsProvider {
+	return &fsProvider{path: path}
+}
+
+func (p *fsProvider) Load(ctx context.Context) (*State, error) {
+	return NewStore(p.path).Load()
+}
+
+func (p *fsProvider) Save(ctx context.Context, st *State) error {
+	return NewStore(p.path).Save(st)
+}
+
+// List scans the runs directory as a sibling of this state file's run
+// directory (runs/<run>/state/ralph_state.json -> runs/).
+func (p *fsProvider) List(ctx context.Context) ([]RunInfo, error) {
+	runsDir := filepath.Dir(filepath.Dir(filepath.Dir(p.path)))
+	runs, _, err := ScanRuns(runsDir)
+	return runs, err
+}
+
+// Watch polls the state file for mtime changes and pushes a fresh snapshot
+// on the returned channel whenever it advances. The channel closes once ctx
+// is cancelled.
+func (p *fsProvider) Watch(ctx context.Context) (<-chan *State, error) {
+	ch := make(chan *State)
+	var lastMod time.Time
+	if info, err := os.Stat(p.path); err == nil {
+		lastMod = info.ModTime()
+	}
+	go func() {
+		defer close(ch)
+		ticker := time.NewTicker(watchPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				info, err := os.Stat(p.path)
+				if err != nil || !info.ModTime().After(lastMod) {
+					continue
+				}
+				lastMod = info.ModTime()
+				st, err := p.Load(ctx)
+				if err != nil {
+					continue
+				}
+				select {
+				case ch <- st:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
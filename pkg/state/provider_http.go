@@ -0,0 +1,89 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// httpProvider is a StateProvider backed by a plain REST endpoint: GET
+// fetches the current state, PUT replaces it. It's deliberately simple (no
+// auth beyond what's baked into the URL, like an S3 presigned URL) so it
+// needs nothing beyond the standard library, unlike the real sqlite/s3
+// backends which need a driver/SDK this tree doesn't vendor.
+type httpProvider struct {
+	url    string
+	client *http.Client
+}
+
+func newHTTPProvider(url string) *httpProvider {
+	return &httpProvider{url: url, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (p *httpProvider) Load(ctx context.Context) (*State, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch state: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch state: unexpected status %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state response: %w", err)
+	}
+
+	var st State
+	if err := json.Unmarshal(body, &st); err != nil {
+		return nil, fmt.Errorf("failed to parse state response: %w", err)
+	}
+	return &st, nil
+}
+
+func (p *httpProvider) Save(ctx context.Context, st *State) error {
+	body, err := json.Marshal(st)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, p.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to save state: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// List isn't supported over the plain GET/PUT REST contract httpProvider
+// speaks: there's no agreed-upon endpoint for enumerating runs.
+func (p *httpProvider) List(ctx context.Context) ([]RunInfo, error) {
+	return nil, fmt.Errorf("listing runs is not supported by the http state backend")
+}
+
+// Watch isn't supported either, for the same reason: no agreed-upon
+// streaming/long-poll endpoint to watch.
+func (p *httpProvider) Watch(ctx context.Context) (<-chan *State, error) {
+	return nil, fmt.Errorf("watching is not supported by the http state backend")
+}
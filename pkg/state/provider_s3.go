@@ -0,0 +1,44 @@
+package state
+
+import (
+	"context"
+	"fmt"
+)
+
+// s3Provider is a StateProvider backed by an object in an S3-compatible
+// bucket (s3://bucket/key), for sharing run state across machines without
+// a server of its own.
+//
+// It isn't wired up yet: talking to S3 properly (SigV4 request signing,
+// credential resolution) needs the AWS SDK, which isn't vendored in this
+// tree (there's no go.mod/module management set up here at all). Use
+// http:// or https:// in the meantime to point at a presigned URL or a
+// simple REST endpoint, which newHTTPProvider can talk to with only the
+// standard library.
+type s3Provider struct {
+	bucketAndKey string
+}
+
+func newS3Provider(bucketAndKey string) *s3Provider {
+	return &s3Provider{bucketAndKey: bucketAndKey}
+}
+
+func (p *s3Provider) errNotAvailable() error {
+	return fmt.Errorf("s3 state backend not available: no AWS SDK vendored in this build (s3://%s)", p.bucketAndKey)
+}
+
+func (p *s3Provider) Load(ctx context.Context) (*State, error) {
+	return nil, p.errNotAvailable()
+}
+
+func (p *s3Provider) Save(ctx context.Context, st *State) error {
+	return p.errNotAvailable()
+}
+
+func (p *s3Provider) List(ctx context.Context) ([]RunInfo, error) {
+	return nil, p.errNotAvailable()
+}
+
+func (p *s3Provider) Watch(ctx context.Context) (<-chan *State, error) {
+	return nil, p.errNotAvailable()
+}
@@ -0,0 +1,45 @@
+package state
+
+import (
+	"context"
+	"fmt"
+)
+
+// sqliteProvider is a StateProvider backed by a single SQLite file,
+// intended for multi-user dashboards that need transactional reads/writes
+// across many runs from one place.
+//
+// It isn't wired up to a real database yet: this tree has no SQLite driver
+// vendored (there's no go.mod/module management set up here at all), so
+// every method fails loudly with what's missing instead of silently
+// degrading to filesystem behavior. Once a driver (e.g.
+// modernc.org/sqlite, to keep this CGo-free like the rest of the CLI) is
+// added as a dependency, these bodies become real transactional
+// read/write/list queries against a `runs` table keyed by run ID.
+type sqliteProvider struct {
+	dsn string
+}
+
+func newSQLiteProvider(dsn string) *sqliteProvider {
+	return &sqliteProvider{dsn: dsn}
+}
+
+func (p *sqliteProvider) errNotAvailable() error {
+	return fmt.Errorf("sqlite state backend not available: no SQLite driver vendored in this build (dsn %q)", p.dsn)
+}
+
+func (p *sqliteProvider) Load(ctx context.Context) (*State, error) {
+	return nil, p.errNotAvailable()
+}
+
+func (p *sqliteProvider) Save(ctx context.Context, st *State) error {
+	return p.errNotAvailable()
+}
+
+func (p *sqliteProvider) List(ctx context.Context) ([]RunInfo, error) {
+	return nil, p.errNotAvailable()
+}
+
+func (p *sqliteProvider) Watch(ctx context.Context) (<-chan *State, error) {
+	return nil, p.errNotAvailable()
+}
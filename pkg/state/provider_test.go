@@ -0,0 +1,114 @@
+package state
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewProviderSchemes(t *testing.T) {
+	tests := []struct {
+		url      string
+		wantType string
+	}{
+		{"./state/ralph_state.json", "*state.fsProvider"},
+		{"file://./state/ralph_state.json", "*state.fsProvider"},
+		{"sqlite://runs.db", "*state.sqliteProvider"},
+		{"s3://bucket/key", "*state.s3Provider"},
+		{"https://example.com/state", "*state.httpProvider"},
+	}
+
+	for _, tt := range tests {
+		p, err := NewProvider(tt.url)
+		if err != nil {
+			t.Fatalf("NewProvider(%q) returned error: %v", tt.url, err)
+		}
+		if got := typeName(p); got != tt.wantType {
+			t.Errorf("NewProvider(%q) = %s, want %s", tt.url, got, tt.wantType)
+		}
+	}
+}
+
+func TestNewProviderUnknownScheme(t *testing.T) {
+	if _, err := NewProvider("ftp://example.com/state"); err == nil {
+		t.Error("Expected an error for an unsupported scheme, got nil")
+	}
+}
+
+func TestFSProviderLoadSave(t *testing.T) {
+	tmpDir := t.TempDir()
+	statePath := filepath.Join(tmpDir, "ralph_state.json")
+
+	p, err := NewProvider(statePath)
+	if err != nil {
+		t.Fatalf("NewProvider failed: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := p.Save(ctx, &State{CurrentCycle: 3, Status: "running"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := p.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.CurrentCycle != 3 {
+		t.Errorf("Expected current cycle 3, got %d", loaded.CurrentCycle)
+	}
+}
+
+func TestFSProviderWatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	statePath := filepath.Join(tmpDir, "ralph_state.json")
+
+	p, err := NewProvider(statePath)
+	if err != nil {
+		t.Fatalf("NewProvider failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := p.Save(ctx, &State{CurrentCycle: 1, Status: "running"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	ch, err := p.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	time.Sleep(watchPollInterval)
+	if err := p.Save(ctx, &State{CurrentCycle: 2, Status: "running"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	select {
+	case st := <-ch:
+		if st.CurrentCycle != 2 {
+			t.Errorf("Expected watched cycle 2, got %d", st.CurrentCycle)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for watch update")
+	}
+}
+
+// typeName returns the dynamic %T representation of v, used here to check
+// NewProvider dispatches to the right concrete StateProvider without
+// exporting the concrete types themselves.
+func typeName(v StateProvider) string {
+	switch v.(type) {
+	case *fsProvider:
+		return "*state.fsProvider"
+	case *sqliteProvider:
+		return "*state.sqliteProvider"
+	case *s3Provider:
+		return "*state.s3Provider"
+	case *httpProvider:
+		return "*state.httpProvider"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,204 @@
+package state
+
+import (
+	"container/list"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// runsCacheCapacity bounds how many distinct runs directories the LRU cache
+// in scanRunsCached will remember at once, so a long-lived process (e.g. a
+// TUI polling several projects) can't grow this without bound.
+const runsCacheCapacity = 32
+
+// Filter narrows the runs ScanRunsPage returns. A zero-value Filter matches
+// every run.
+type Filter struct {
+	// Status, if non-empty, must be a substring of RunInfo.Status.
+	Status string
+	// MinCycles, if > 0, requires RunInfo.Cycles >= MinCycles.
+	MinCycles int
+	// Since, if non-zero, requires RunInfo.ModTime to be at or after it.
+	Since time.Time
+}
+
+// matches reports whether run satisfies f.
+func (f Filter) matches(run RunInfo) bool {
+	if f.Status != "" && !strings.Contains(run.Status, f.Status) {
+		return false
+	}
+	if f.MinCycles > 0 && run.Cycles < f.MinCycles {
+		return false
+	}
+	if !f.Since.IsZero() && run.ModTime.Before(f.Since) {
+		return false
+	}
+	return true
+}
+
+// runsCacheEntry is the value stored in the runs cache, alongside the mtime
+// the directory had when it was scanned.
+type runsCacheEntry struct {
+	runsDir string
+	mtime   time.Time
+	runs    []RunInfo
+	state   *State
+}
+
+// runsCache is an in-memory LRU cache of scanRunsUncached results, keyed by
+// runsDir and invalidated whenever its runsFingerprint changes.
+type runsCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+var sharedRunsCache = newRunsCache(runsCacheCapacity)
+
+func newRunsCache(capacity int) *runsCache {
+	return &runsCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// get returns a cached scan for runsDir if one exists and was taken at the
+// given mtime; otherwise it reports ok == false.
+func (c *runsCache) get(runsDir string, mtime time.Time) (runsCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[runsDir]
+	if !ok {
+		return runsCacheEntry{}, false
+	}
+	entry := el.Value.(runsCacheEntry)
+	if !entry.mtime.Equal(mtime) {
+		return runsCacheEntry{}, false
+	}
+	c.order.MoveToFront(el)
+	return entry, true
+}
+
+// put stores a freshly computed scan, evicting the least recently used
+// entry if the cache is over capacity.
+func (c *runsCache) put(entry runsCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[entry.runsDir]; ok {
+		c.order.MoveToFront(el)
+		el.Value = entry
+		return
+	}
+
+	el := c.order.PushFront(entry)
+	c.entries[entry.runsDir] = el
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(runsCacheEntry).runsDir)
+	}
+}
+
+// runsFingerprint returns an invalidation key for runsDir: the later of its
+// own mtime and the mtime of every session run's cycles/ subdirectory.
+// New cycles are written two levels below runsDir
+// (run_.../cycles/cycle_NNNN/), which never touches runsDir's own mtime, so
+// watching only runsDir would keep serving a stale cycle count for an
+// actively-progressing run; folding in each run's cycles/ dir catches that
+// (a new cycle directory bumps its parent cycles/ dir's mtime).
+func runsFingerprint(runsDir string) (time.Time, error) {
+	info, err := os.Stat(runsDir)
+	if err != nil {
+		return time.Time{}, err
+	}
+	latest := info.ModTime()
+
+	entries, err := os.ReadDir(runsDir)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || !runIDPattern.MatchString(entry.Name()) {
+			continue
+		}
+		cyclesInfo, err := os.Stat(filepath.Join(runsDir, entry.Name(), "cycles"))
+		if err != nil {
+			continue
+		}
+		if cyclesInfo.ModTime().After(latest) {
+			latest = cyclesInfo.ModTime()
+		}
+	}
+
+	return latest, nil
+}
+
+// scanRunsCached returns scanRunsUncached's result for runsDir, reusing the
+// cached scan when runsFingerprint hasn't changed since it was last
+// computed.
+func scanRunsCached(runsDir string) ([]RunInfo, *State, error) {
+	mtime, err := runsFingerprint(runsDir)
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if entry, ok := sharedRunsCache.get(runsDir, mtime); ok {
+		return entry.runs, entry.state, nil
+	}
+
+	runs, st, err := scanRunsUncached(runsDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sharedRunsCache.put(runsCacheEntry{runsDir: runsDir, mtime: mtime, runs: runs, state: st})
+	return runs, st, nil
+}
+
+// ScanRunsPage returns a filtered, paginated slice of ScanRuns's result,
+// along with the total number of runs matching filter (before pagination),
+// so callers can render "page X of Y" without re-scanning. Repeated calls
+// against an unchanged runsDir reuse an in-memory cache keyed by
+// runsFingerprint instead of re-reading it from disk.
+func ScanRunsPage(runsDir string, offset, limit int, filter Filter) ([]RunInfo, int, error) {
+	runs, _, err := scanRunsCached(runsDir)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var filtered []RunInfo
+	for _, run := range runs {
+		if filter.matches(run) {
+			filtered = append(filtered, run)
+		}
+	}
+	total := len(filtered)
+
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= total {
+		return []RunInfo{}, total, nil
+	}
+
+	end := total
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+
+	return filtered[offset:end], total, nil
+}
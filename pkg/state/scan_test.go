@@ -0,0 +1,164 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRunIDPatternRejectsLookalikes(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"run_20260216_175000_000", true},
+		{"run_garbage", false},
+		{"run_2026021_175000_000", false},
+		{"cycle_0001", false},
+	}
+
+	for _, tt := range tests {
+		if got := runIDPattern.MatchString(tt.name); got != tt.want {
+			t.Errorf("runIDPattern.MatchString(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCycleNamePatternRejectsLookalikes(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"cycle_0001", true},
+		{"cycle_1", false},
+		{"cycle_garbage", false},
+		{"run_20260216_175000_000", false},
+	}
+
+	for _, tt := range tests {
+		if got := cycleNamePattern.MatchString(tt.name); got != tt.want {
+			t.Errorf("cycleNamePattern.MatchString(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestScanRunsPageFiltersAndPaginates(t *testing.T) {
+	tmpDir := t.TempDir()
+	runsDir := filepath.Join(tmpDir, "runs")
+
+	mustMkdirAll(t, filepath.Join(runsDir, "cycle_0001"))
+	mustMkdirAll(t, filepath.Join(runsDir, "cycle_0002"))
+	mustMkdirAll(t, filepath.Join(runsDir, "cycle_0003"))
+	mustWriteFile(t, filepath.Join(runsDir, "cycle_0001", "metrics.json"), `{"cycle": 1}`)
+
+	page, total, err := ScanRunsPage(runsDir, 0, 1, Filter{Status: "Complete"})
+	if err != nil {
+		t.Fatalf("ScanRunsPage failed: %v", err)
+	}
+	if total != 1 {
+		t.Errorf("Expected 1 matching run, got %d", total)
+	}
+	if len(page) != 1 || page[0].Name != "cycle_0001" {
+		t.Errorf("Expected page [cycle_0001], got %+v", page)
+	}
+
+	page, total, err = ScanRunsPage(runsDir, 1, 1, Filter{})
+	if err != nil {
+		t.Fatalf("ScanRunsPage failed: %v", err)
+	}
+	if total != 3 {
+		t.Errorf("Expected 3 total runs, got %d", total)
+	}
+	if len(page) != 1 || page[0].Name != "cycle_0002" {
+		t.Errorf("Expected page [cycle_0002], got %+v", page)
+	}
+
+	page, _, err = ScanRunsPage(runsDir, 10, 1, Filter{})
+	if err != nil {
+		t.Fatalf("ScanRunsPage failed: %v", err)
+	}
+	if len(page) != 0 {
+		t.Errorf("Expected empty page past the end, got %+v", page)
+	}
+}
+
+func TestScanRunsPageReusesCacheUntilMtimeChanges(t *testing.T) {
+	tmpDir := t.TempDir()
+	runsDir := filepath.Join(tmpDir, "runs")
+	mustMkdirAll(t, filepath.Join(runsDir, "cycle_0001"))
+
+	if _, _, err := ScanRunsPage(runsDir, 0, 10, Filter{}); err != nil {
+		t.Fatalf("ScanRunsPage failed: %v", err)
+	}
+
+	info, err := os.Stat(runsDir)
+	if err != nil {
+		t.Fatalf("Failed to stat runs dir: %v", err)
+	}
+	if _, ok := sharedRunsCache.get(runsDir, info.ModTime()); !ok {
+		t.Fatal("Expected a cache hit right after populating the cache")
+	}
+
+	// Bump the directory's mtime to simulate a new run appearing, and
+	// confirm the cache entry is treated as stale.
+	newMtime := info.ModTime().Add(time.Second)
+	if err := os.Chtimes(runsDir, newMtime, newMtime); err != nil {
+		t.Fatalf("Failed to change runs dir mtime: %v", err)
+	}
+	if _, ok := sharedRunsCache.get(runsDir, newMtime); ok {
+		t.Fatal("Expected a cache miss after the runs dir mtime changed")
+	}
+
+	mustMkdirAll(t, filepath.Join(runsDir, "cycle_0002"))
+	runs, _, err := ScanRunsPage(runsDir, 0, 10, Filter{})
+	if err != nil {
+		t.Fatalf("ScanRunsPage failed: %v", err)
+	}
+	if len(runs) != 2 {
+		t.Errorf("Expected 2 runs after rescan, got %d", len(runs))
+	}
+}
+
+func TestScanRunsPageDetectsNewCycleInSessionLayout(t *testing.T) {
+	tmpDir := t.TempDir()
+	runsDir := filepath.Join(tmpDir, "runs")
+	runDir := filepath.Join(runsDir, "run_20260216_175000_000")
+	cyclesDir := filepath.Join(runDir, "cycles")
+	mustMkdirAll(t, filepath.Join(cyclesDir, "cycle_0001"))
+
+	runs, _, err := ScanRunsPage(runsDir, 0, 10, Filter{})
+	if err != nil {
+		t.Fatalf("ScanRunsPage failed: %v", err)
+	}
+	if len(runs) != 1 || runs[0].Cycles != 1 {
+		t.Fatalf("Expected 1 run with 1 cycle, got %+v", runs)
+	}
+
+	// Add a new cycle directly under the existing session run's cycles/
+	// dir. This never touches runsDir's own mtime, only cyclesDir's, so a
+	// fingerprint keyed solely on runsDir would miss it.
+	mustMkdirAll(t, filepath.Join(cyclesDir, "cycle_0002"))
+
+	runs, _, err = ScanRunsPage(runsDir, 0, 10, Filter{})
+	if err != nil {
+		t.Fatalf("ScanRunsPage failed: %v", err)
+	}
+	if len(runs) != 1 || runs[0].Cycles != 2 {
+		t.Errorf("Expected the rescan to pick up the new cycle, got %+v", runs)
+	}
+}
+
+func mustMkdirAll(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(path, 0755); err != nil {
+		t.Fatalf("Failed to create directory %q: %v", path, err)
+	}
+}
+
+func mustWriteFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file %q: %v", path, err)
+	}
+}
@@ -1,27 +1,44 @@
 package state
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"time"
 )
 
+// runIDPattern matches a session run directory name, e.g.
+// run_20260216_175000_000, rejecting lookalikes like run_garbage that a
+// naive prefix check would let through.
+var runIDPattern = regexp.MustCompile(`^run_\d{8}_\d{6}_\d{3}$`)
+
+// cycleNamePattern matches a cycle directory name, e.g. cycle_0007.
+var cycleNamePattern = regexp.MustCompile(`^cycle_\d{4}$`)
+
+// IsCycleDirName reports whether name is a finalized cycle directory name
+// (e.g. cycle_0007), rejecting lookalikes a naive "cycle_" prefix check
+// would let through, including the cycle_NNNN.tmp working directories a
+// crashed run leaves behind before FinalizeCycleDir renames them (see
+// pkg/paths' ResumeRun).
+func IsCycleDirName(name string) bool {
+	return cycleNamePattern.MatchString(name)
+}
+
 // CycleMetrics represents metrics from a cycle.
 type CycleMetrics struct {
-	Cycle int                    `json:"cycle"`
-	Result map[string]interface{} `json:"result"`
+	Cycle   int                    `json:"cycle"`
+	Result  map[string]interface{} `json:"result"`
 	Runtime map[string]interface{} `json:"runtime"`
 }
 
 // CycleSnapshot represents a snapshot of a cycle.
 type CycleSnapshot struct {
-	CycleNumber    int                    `json:"cycle_number"`
-	Metrics        CycleMetrics           `json:"metrics"`
-	Timestamp      string                 `json:"timestamp"`
-	BestMetric     *float64               `json:"best_metric,omitempty"`
-	Analysis       map[string]interface{} `json:"analysis,omitempty"`
+	CycleNumber int                    `json:"cycle_number"`
+	Metrics     CycleMetrics           `json:"metrics"`
+	Timestamp   string                 `json:"timestamp"`
+	BestMetric  *float64               `json:"best_metric,omitempty"`
+	Analysis    map[string]interface{} `json:"analysis,omitempty"`
 }
 
 // State represents the Ralph ML Loop state.
@@ -36,41 +53,16 @@ type State struct {
 	LastUpdate   *string                `json:"last_update,omitempty"`
 }
 
-// LoadState loads a state from a JSON file.
+// LoadState loads a state from path via a Store, applying any schema
+// migrations needed.
 func LoadState(path string) (*State, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read state file: %w", err)
-	}
-
-	var state State
-	if err := json.Unmarshal(data, &state); err != nil {
-		return nil, fmt.Errorf("failed to parse state file: %w", err)
-	}
-
-	return &state, nil
+	return NewStore(path).Load()
 }
 
-// SaveState saves a state to a JSON file.
+// SaveState saves a state to path via a Store, writing it atomically and
+// stamping it with the current schema version.
 func SaveState(path string, state *State) error {
-	// Create directory if it doesn't exist
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create state directory: %w", err)
-	}
-
-	// Marshal with indentation
-	data, err := json.MarshalIndent(state, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal state: %w", err)
-	}
-
-	// Write file
-	if err := os.WriteFile(path, data, 0644); err != nil {
-		return fmt.Errorf("failed to write state file: %w", err)
-	}
-
-	return nil
+	return NewStore(path).Save(state)
 }
 
 // RunInfo represents information about a run.
@@ -80,10 +72,18 @@ type RunInfo struct {
 	Status   string
 	Cycles   int
 	CycleDir string
+	ModTime  time.Time
 }
 
 // ScanRuns scans the runs directory and returns information about all runs.
 func ScanRuns(runsDir string) ([]RunInfo, *State, error) {
+	return scanRunsUncached(runsDir)
+}
+
+// scanRunsUncached does the actual directory walk behind ScanRuns and
+// ScanRunsPage (via the runs cache); callers needing repeated, cheap
+// re-scans of the same directory should go through ScanRunsPage instead.
+func scanRunsUncached(runsDir string) ([]RunInfo, *State, error) {
 	var runs []RunInfo
 	var stateFile *State
 
@@ -106,8 +106,13 @@ func ScanRuns(runsDir string) ([]RunInfo, *State, error) {
 		name := entry.Name()
 		runPath := filepath.Join(runsDir, name)
 
-		// Check for session layout (runs/run_YYYY.../)
-		if len(name) >= 4 && name[:4] == "run_" {
+		var modTime time.Time
+		if info, err := entry.Info(); err == nil {
+			modTime = info.ModTime()
+		}
+
+		// Check for session layout (runs/run_YYYYMMDD_HHMMSS_mmm/)
+		if runIDPattern.MatchString(name) {
 			sessionPath := runPath
 			cyclesPath := filepath.Join(sessionPath, "cycles")
 			statePath := filepath.Join(sessionPath, "state", "ralph_state.json")
@@ -124,7 +129,7 @@ func ScanRuns(runsDir string) ([]RunInfo, *State, error) {
 			var cycleCount int
 			if cyclesEntries, err := os.ReadDir(cyclesPath); err == nil {
 				for _, ce := range cyclesEntries {
-					if ce.IsDir() && len(ce.Name()) >= 6 && ce.Name()[:6] == "cycle_" {
+					if ce.IsDir() && cycleNamePattern.MatchString(ce.Name()) {
 						cycleCount++
 					}
 				}
@@ -136,12 +141,13 @@ func ScanRuns(runsDir string) ([]RunInfo, *State, error) {
 				Status:   fmt.Sprintf("🧪 Session (%d cycles)", cycleCount),
 				Cycles:   cycleCount,
 				CycleDir: cyclesPath,
+				ModTime:  modTime,
 			})
 			continue
 		}
 
 		// Check for legacy layout (runs/cycle_XXXX/)
-		if len(name) >= 6 && name[:6] == "cycle_" {
+		if cycleNamePattern.MatchString(name) {
 			metricsPath := filepath.Join(runPath, "metrics.json")
 			hasMetrics := false
 			if stat, err := os.Stat(metricsPath); err == nil && !stat.IsDir() {
@@ -159,16 +165,18 @@ func ScanRuns(runsDir string) ([]RunInfo, *State, error) {
 				Status:   status,
 				Cycles:   1,
 				CycleDir: runsDir,
+				ModTime:  modTime,
 			})
 			continue
 		}
 
 		// Unknown directory
 		runs = append(runs, RunInfo{
-			Name:     name,
-			Path:     runPath,
-			Status:   "❓ Unknown",
-			Cycles:   0,
+			Name:    name,
+			Path:    runPath,
+			Status:  "❓ Unknown",
+			Cycles:  0,
+			ModTime: modTime,
 		})
 	}
 
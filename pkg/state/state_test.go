@@ -18,7 +18,7 @@ func TestLoadState(t *testing.T) {
 			},
 		},
 		CurrentCycle: 5,
-		BestMetric:  float64Ptr(0.95),
+		BestMetric:   float64Ptr(0.95),
 		BestCycle:    3,
 		Status:       "running",
 	}
@@ -154,7 +154,7 @@ func TestFormatTime(t *testing.T) {
 	// Test valid timestamp
 	timestamp := "2026-02-16T17:50:00Z"
 	formatted := FormatTime(timestamp)
-	
+
 	// Should not be N/A
 	if formatted == "N/A" {
 		t.Error("Expected formatted time, got N/A")
@@ -0,0 +1,235 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// CurrentSchemaVersion is the schema_version a Store stamps onto every
+// state file it writes. Bump this whenever State's on-disk shape changes
+// in a way old readers can't handle directly, and add a migration below so
+// runs started on an older build keep resuming.
+const CurrentSchemaVersion = 1
+
+// migration upgrades a raw state document from one schema version to the
+// next, in place.
+type migration func(doc map[string]interface{}) error
+
+// migrations are keyed by the schema_version they upgrade FROM. A state
+// file with no schema_version field is treated as version 0.
+var migrations = map[int]migration{
+	// 0 -> 1: schema_version field introduced; no field shape changes, so
+	// there's nothing to migrate.
+	0: func(doc map[string]interface{}) error { return nil },
+}
+
+// Store loads and saves a State at a fixed path, writing atomically via
+// write-to-temp-then-rename (plus an fsync of both the file and its
+// directory on Unix) so a crash or power loss never leaves a partially
+// written ralph_state.json behind. A sibling .lock file coordinates
+// concurrent readers/writers across processes.
+type Store struct {
+	path        string
+	keepBackups int
+}
+
+// NewStore returns a Store for the state file at path.
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// SetKeepBackups makes Save rotate the previous n versions of the state
+// file to path.1, path.2, ... (oldest dropped once the cap is reached)
+// before writing the new one, so a corrupted latest write can still be
+// recovered from path.1. The default, 0, keeps no backups.
+func (s *Store) SetKeepBackups(n int) {
+	s.keepBackups = n
+}
+
+// Load reads the state file, applying any registered migrations needed to
+// bring it up to CurrentSchemaVersion. If the primary file is missing or
+// fails to parse (most likely because a crash landed between writing the
+// temp file and renaming it into place), Load transparently falls back to
+// the temp file and then the most recent backup, so a partial write never
+// bricks a long run.
+func (s *Store) Load() (*State, error) {
+	unlock, err := lockFile(s.path + ".lock")
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock state file: %w", err)
+	}
+	defer unlock()
+
+	st, primaryErr := s.loadFrom(s.path)
+	if primaryErr == nil {
+		return st, nil
+	}
+
+	for _, candidate := range []string{s.path + ".tmp", s.path + ".1"} {
+		if st, err := s.loadFrom(candidate); err == nil {
+			return st, nil
+		}
+	}
+
+	return nil, primaryErr
+}
+
+// loadFrom reads and migrates a state document from an exact path, with no
+// fallback to other candidates.
+func (s *Store) loadFrom(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse state file: %w", err)
+	}
+
+	version := 0
+	if v, ok := doc["schema_version"].(float64); ok {
+		version = int(v)
+	}
+
+	if version > CurrentSchemaVersion {
+		return nil, fmt.Errorf("state file has schema version %d, newer than this build understands (%d)", version, CurrentSchemaVersion)
+	}
+
+	for version < CurrentSchemaVersion {
+		migrate, ok := migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("no migration registered for schema version %d", version)
+		}
+		if err := migrate(doc); err != nil {
+			return nil, fmt.Errorf("failed to migrate state from schema version %d: %w", version, err)
+		}
+		version++
+	}
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-marshal migrated state: %w", err)
+	}
+
+	var st State
+	if err := json.Unmarshal(migrated, &st); err != nil {
+		return nil, fmt.Errorf("failed to parse migrated state: %w", err)
+	}
+
+	return &st, nil
+}
+
+// Save writes state to disk atomically, stamping it with
+// CurrentSchemaVersion. If KeepBackups is set, the previous file is
+// rotated into path.1 (shifting older backups up) before the new one is
+// put in place.
+func (s *Store) Save(st *State) error {
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	unlock, err := lockFile(s.path + ".lock")
+	if err != nil {
+		return fmt.Errorf("failed to lock state file: %w", err)
+	}
+	defer unlock()
+
+	raw, err := json.Marshal(st)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+	doc["schema_version"] = CurrentSchemaVersion
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	// A fixed name (rather than a randomly-suffixed os.CreateTemp file) so
+	// Load can find it by convention if a crash lands between this write
+	// and the rename below.
+	tmpPath := s.path + ".tmp"
+	tmpFile, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create temp state file: %w", err)
+	}
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write temp state file: %w", err)
+	}
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to fsync temp state file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temp state file: %w", err)
+	}
+
+	if err := s.rotateBackups(); err != nil {
+		return fmt.Errorf("failed to rotate state backups: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return fmt.Errorf("failed to rename temp state file into place: %w", err)
+	}
+
+	syncDir(dir)
+
+	return nil
+}
+
+// rotateBackups shifts path.1 -> path.2 -> ... -> path.keepBackups
+// (dropping anything beyond that), then moves the current path to path.1,
+// preserving each file's original mtime so ScanRuns keeps sorting by real
+// update time rather than backup-rotation time. A no-op when KeepBackups
+// is 0 or the current file doesn't exist yet (first save of a new run).
+func (s *Store) rotateBackups() error {
+	if s.keepBackups <= 0 {
+		return nil
+	}
+
+	for i := s.keepBackups; i >= 1; i-- {
+		src := fmt.Sprintf("%s.%d", s.path, i)
+		info, err := os.Stat(src)
+		if err != nil {
+			continue
+		}
+		if i == s.keepBackups {
+			if err := os.Remove(src); err != nil {
+				return err
+			}
+			continue
+		}
+		dst := fmt.Sprintf("%s.%d", s.path, i+1)
+		if err := os.Rename(src, dst); err != nil {
+			return err
+		}
+		if err := os.Chtimes(dst, info.ModTime(), info.ModTime()); err != nil {
+			return err
+		}
+	}
+
+	info, err := os.Stat(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	dst := s.path + ".1"
+	if err := os.Rename(s.path, dst); err != nil {
+		return err
+	}
+	return os.Chtimes(dst, info.ModTime(), info.ModTime())
+}
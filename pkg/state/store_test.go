@@ -0,0 +1,192 @@
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStoreSaveStampsSchemaVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	statePath := filepath.Join(tmpDir, "ralph_state.json")
+
+	store := NewStore(statePath)
+	if err := store.Save(&State{CurrentCycle: 2, Status: "running"}); err != nil {
+		t.Fatalf("Failed to save state: %v", err)
+	}
+
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatalf("Failed to read state file: %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Failed to parse state file: %v", err)
+	}
+
+	version, ok := doc["schema_version"].(float64)
+	if !ok {
+		t.Fatal("Expected schema_version to be present")
+	}
+	if int(version) != CurrentSchemaVersion {
+		t.Errorf("Expected schema_version %d, got %d", CurrentSchemaVersion, int(version))
+	}
+}
+
+func TestStoreLoadMigratesLegacyFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	statePath := filepath.Join(tmpDir, "ralph_state.json")
+
+	// A legacy state file, written before schema_version existed.
+	legacy := `{"config":{},"current_cycle":7,"best_metric":0.8,"best_cycle":5,"status":"running"}`
+	if err := os.WriteFile(statePath, []byte(legacy), 0644); err != nil {
+		t.Fatalf("Failed to write legacy state file: %v", err)
+	}
+
+	st, err := NewStore(statePath).Load()
+	if err != nil {
+		t.Fatalf("Failed to load legacy state: %v", err)
+	}
+
+	if st.CurrentCycle != 7 {
+		t.Errorf("Expected current cycle 7, got %d", st.CurrentCycle)
+	}
+	if st.BestMetric == nil || *st.BestMetric != 0.8 {
+		t.Errorf("Expected best metric 0.8, got %v", st.BestMetric)
+	}
+}
+
+func TestStoreLoadRejectsUnknownFutureVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	statePath := filepath.Join(tmpDir, "ralph_state.json")
+
+	future := `{"schema_version":999,"current_cycle":1,"status":"running"}`
+	if err := os.WriteFile(statePath, []byte(future), 0644); err != nil {
+		t.Fatalf("Failed to write future state file: %v", err)
+	}
+
+	if _, err := NewStore(statePath).Load(); err == nil {
+		t.Error("Expected error loading a state file with no migration path, got nil")
+	}
+}
+
+func TestStoreSaveRotatesBackups(t *testing.T) {
+	tmpDir := t.TempDir()
+	statePath := filepath.Join(tmpDir, "ralph_state.json")
+
+	store := NewStore(statePath)
+	store.SetKeepBackups(2)
+
+	for cycle := 1; cycle <= 4; cycle++ {
+		if err := store.Save(&State{CurrentCycle: cycle, Status: "running"}); err != nil {
+			t.Fatalf("Failed to save state (cycle %d): %v", cycle, err)
+		}
+	}
+
+	// After 4 saves with KeepBackups=2: latest is cycle 4, .1 is cycle 3,
+	// .2 is cycle 2; cycle 1 should have been dropped.
+	latest, err := NewStore(statePath).Load()
+	if err != nil {
+		t.Fatalf("Failed to load latest state: %v", err)
+	}
+	if latest.CurrentCycle != 4 {
+		t.Errorf("Expected latest cycle 4, got %d", latest.CurrentCycle)
+	}
+
+	backup1, err := NewStore(statePath + ".1").Load()
+	if err != nil {
+		t.Fatalf("Failed to load backup .1: %v", err)
+	}
+	if backup1.CurrentCycle != 3 {
+		t.Errorf("Expected backup .1 cycle 3, got %d", backup1.CurrentCycle)
+	}
+
+	backup2, err := NewStore(statePath + ".2").Load()
+	if err != nil {
+		t.Fatalf("Failed to load backup .2: %v", err)
+	}
+	if backup2.CurrentCycle != 2 {
+		t.Errorf("Expected backup .2 cycle 2, got %d", backup2.CurrentCycle)
+	}
+
+	if _, err := os.Stat(statePath + ".3"); !os.IsNotExist(err) {
+		t.Error("Expected no .3 backup beyond the KeepBackups cap")
+	}
+}
+
+func TestStoreLoadFallsBackToTmpFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	statePath := filepath.Join(tmpDir, "ralph_state.json")
+
+	// Simulate a crash between writing the temp file and renaming it: only
+	// the .tmp file exists, the primary path doesn't.
+	tmpContent, err := json.Marshal(map[string]interface{}{
+		"current_cycle":  9,
+		"status":         "running",
+		"schema_version": CurrentSchemaVersion,
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal tmp content: %v", err)
+	}
+	if err := os.WriteFile(statePath+".tmp", tmpContent, 0644); err != nil {
+		t.Fatalf("Failed to write tmp state file: %v", err)
+	}
+
+	st, err := NewStore(statePath).Load()
+	if err != nil {
+		t.Fatalf("Failed to load state via tmp fallback: %v", err)
+	}
+	if st.CurrentCycle != 9 {
+		t.Errorf("Expected current cycle 9, got %d", st.CurrentCycle)
+	}
+}
+
+func TestStoreLoadFallsBackToBackupOnCorruptPrimary(t *testing.T) {
+	tmpDir := t.TempDir()
+	statePath := filepath.Join(tmpDir, "ralph_state.json")
+
+	if err := os.WriteFile(statePath, []byte("{not valid json"), 0644); err != nil {
+		t.Fatalf("Failed to write corrupt state file: %v", err)
+	}
+
+	backupContent, err := json.Marshal(map[string]interface{}{
+		"current_cycle":  6,
+		"status":         "running",
+		"schema_version": CurrentSchemaVersion,
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal backup content: %v", err)
+	}
+	if err := os.WriteFile(statePath+".1", backupContent, 0644); err != nil {
+		t.Fatalf("Failed to write backup state file: %v", err)
+	}
+
+	st, err := NewStore(statePath).Load()
+	if err != nil {
+		t.Fatalf("Failed to load state via backup fallback: %v", err)
+	}
+	if st.CurrentCycle != 6 {
+		t.Errorf("Expected current cycle 6 from backup, got %d", st.CurrentCycle)
+	}
+}
+
+func TestStoreSaveNoPartialFileOnRename(t *testing.T) {
+	tmpDir := t.TempDir()
+	statePath := filepath.Join(tmpDir, "ralph_state.json")
+
+	if err := NewStore(statePath).Save(&State{CurrentCycle: 1, Status: "running"}); err != nil {
+		t.Fatalf("Failed to save state: %v", err)
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to read temp dir: %v", err)
+	}
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) == ".tmp" {
+			t.Errorf("Expected no leftover temp file, found %q", e.Name())
+		}
+	}
+}
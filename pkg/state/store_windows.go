@@ -0,0 +1,48 @@
+//go:build windows
+
+package state
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// lockFile takes an exclusive lock on path (creating it if needed) and
+// returns a function that releases it.
+//
+// The obvious way to do this is LockFileEx, but that's only exposed by
+// golang.org/x/sys/windows, not the standard syscall package, and there's
+// no go.mod/module management set up in this tree to vendor it (the same
+// constraint provider_s3.go and provider_sqlite.go are under). Instead,
+// this opens the file via the stdlib syscall package's own CreateFile with
+// an empty share mode: Windows refuses a second handle to the same file
+// while this one stays open, which gives the same mutual-exclusion
+// guarantee LockFileEx would, without the extra dependency.
+func lockFile(path string) (func(), error) {
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+
+	handle, err := syscall.CreateFile(
+		pathPtr,
+		syscall.GENERIC_READ|syscall.GENERIC_WRITE,
+		0, // no sharing: a second CreateFile on this path fails until we close ours
+		nil,
+		syscall.OPEN_ALWAYS,
+		syscall.FILE_ATTRIBUTE_NORMAL,
+		0,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+
+	return func() {
+		syscall.CloseHandle(handle)
+	}, nil
+}
+
+// syncDir is a no-op on Windows: renames are durable without an explicit
+// directory fsync, and directory handles can't be fsynced the way they
+// can on Unix.
+func syncDir(dir string) {}
@@ -0,0 +1,173 @@
+package state
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// watchPollTick is how often Watch checks the state file's mtime. It's
+// deliberately tighter than watchPollInterval (used by fsProvider.Watch)
+// since Watch additionally debounces before emitting, so a short tick just
+// controls how quickly a change is noticed, not how often events fire.
+const watchPollTick = 50 * time.Millisecond
+
+// watchDebounce is how long a state file's mtime must stay unchanged before
+// Watch loads and diffs it, so a burst of writes (e.g. Store.Save's
+// temp-then-rename, or several quick saves) only produces one event instead
+// of one per write.
+const watchDebounce = 100 * time.Millisecond
+
+// EventType identifies what changed between two successive loads of a state
+// file.
+type EventType string
+
+const (
+	// CycleAdded fires when a new CycleSnapshot appears in History.
+	CycleAdded EventType = "cycle_added"
+	// BestMetricImproved fires when BestMetric is set for the first time or
+	// increases.
+	BestMetricImproved EventType = "best_metric_improved"
+	// StatusChanged fires when Status changes (e.g. running -> completed).
+	StatusChanged EventType = "status_changed"
+)
+
+// Event describes one detected change to a watched state file.
+type Event struct {
+	Type     EventType
+	State    *State
+	Previous *State
+}
+
+// Watch polls the state file at path and, by diffing each successive load
+// against the last one it saw, emits a typed Event on the returned channel
+// for every new cycle, best-metric improvement, or status change.
+//
+// This was asked for as an fsnotify-backed watch, but fsnotify isn't
+// vendored in this tree (there's no go.mod/module management set up here at
+// all, the same constraint provider_s3.go and provider_sqlite.go are under),
+// so it's implemented as a debounced mtime poll instead. Swapping in real
+// fsnotify later shouldn't need to change this function's signature or the
+// Event contract, only the loop inside it.
+//
+// The returned stop function cancels the watch; it blocks until the watch
+// goroutine has checked the file one last time (flushing any pending event)
+// and closed the channel, so a caller stopping on SIGINT doesn't lose the
+// final update. It's safe to call from more than one goroutine, or more than
+// once, concurrently or not — only the first call has any effect, and every
+// call blocks until the watch goroutine has actually stopped.
+func Watch(path string) (<-chan Event, func(), error) {
+	prev, err := NewStore(path).Load()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, err
+	}
+
+	var lastMod time.Time
+	if info, statErr := os.Stat(path); statErr == nil {
+		lastMod = info.ModTime()
+	}
+
+	ch := make(chan Event)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	// check loads and diffs the file if it changed and has been stable for
+	// watchDebounce (or, when final is set, regardless of how recently it
+	// changed), returning the resulting events (if any) and the mtime they
+	// were computed from.
+	check := func(processedMod time.Time, final bool) ([]Event, time.Time) {
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().After(processedMod) {
+			return nil, processedMod
+		}
+		if !final && time.Since(info.ModTime()) < watchDebounce {
+			return nil, processedMod
+		}
+
+		st, err := NewStore(path).Load()
+		if err != nil {
+			return nil, processedMod
+		}
+
+		events := diffEvents(prev, st)
+		prev = st
+		return events, info.ModTime()
+	}
+
+	go func() {
+		defer close(stopped)
+		defer close(ch)
+
+		ticker := time.NewTicker(watchPollTick)
+		defer ticker.Stop()
+
+		processedMod := lastMod
+		for {
+			select {
+			case <-done:
+				// done is already closed, so a send guarded by select{ch<-ev;
+				// case <-done} could drop the flush event in the race between
+				// the two ready cases; send directly instead, relying on the
+				// caller to be draining ch concurrently (as stop's contract
+				// requires).
+				events, _ := check(processedMod, true)
+				for _, ev := range events {
+					ch <- ev
+				}
+				return
+			case <-ticker.C:
+				events, newMod := check(processedMod, false)
+				processedMod = newMod
+				for _, ev := range events {
+					select {
+					case ch <- ev:
+					case <-done:
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	var stopOnce sync.Once
+	stop := func() {
+		stopOnce.Do(func() { close(done) })
+		<-stopped
+	}
+
+	return ch, stop, nil
+}
+
+// diffEvents compares prev (may be nil, if the state file didn't exist yet)
+// against st and returns the events the transition produced, in a fixed
+// order: a new cycle, then a best-metric improvement, then a status change.
+func diffEvents(prev, st *State) []Event {
+	var events []Event
+
+	prevCycles := 0
+	if prev != nil {
+		prevCycles = len(prev.History)
+	}
+	if len(st.History) > prevCycles {
+		events = append(events, Event{Type: CycleAdded, State: st, Previous: prev})
+	}
+
+	var prevBest *float64
+	if prev != nil {
+		prevBest = prev.BestMetric
+	}
+	if st.BestMetric != nil && (prevBest == nil || *st.BestMetric > *prevBest) {
+		events = append(events, Event{Type: BestMetricImproved, State: st, Previous: prev})
+	}
+
+	prevStatus := ""
+	if prev != nil {
+		prevStatus = prev.Status
+	}
+	if st.Status != prevStatus {
+		events = append(events, Event{Type: StatusChanged, State: st, Previous: prev})
+	}
+
+	return events
+}
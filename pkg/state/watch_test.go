@@ -0,0 +1,265 @@
+package state
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWatchEmitsCycleAddedAndBestMetricImproved(t *testing.T) {
+	tmpDir := t.TempDir()
+	statePath := filepath.Join(tmpDir, "ralph_state.json")
+
+	store := NewStore(statePath)
+	if err := store.Save(&State{Status: "running", CurrentCycle: 0}); err != nil {
+		t.Fatalf("Failed to save initial state: %v", err)
+	}
+
+	ch, stop, err := Watch(statePath)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	defer stop()
+
+	time.Sleep(watchDebounce * 2)
+
+	metric := 0.5
+	if err := store.Save(&State{
+		Status:       "running",
+		CurrentCycle: 1,
+		BestMetric:   &metric,
+		BestCycle:    1,
+		History:      []CycleSnapshot{{CycleNumber: 1}},
+	}); err != nil {
+		t.Fatalf("Failed to save updated state: %v", err)
+	}
+
+	var got []EventType
+	timeout := time.After(3 * time.Second)
+	for len(got) < 2 {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				t.Fatalf("Channel closed early, got events: %v", got)
+			}
+			got = append(got, ev.Type)
+		case <-timeout:
+			t.Fatalf("Timed out waiting for events, got: %v", got)
+		}
+	}
+
+	foundCycle, foundBest := false, false
+	for _, typ := range got {
+		if typ == CycleAdded {
+			foundCycle = true
+		}
+		if typ == BestMetricImproved {
+			foundBest = true
+		}
+	}
+	if !foundCycle {
+		t.Errorf("Expected a CycleAdded event, got %v", got)
+	}
+	if !foundBest {
+		t.Errorf("Expected a BestMetricImproved event, got %v", got)
+	}
+}
+
+func TestWatchToleratesMissingFileUntilFirstWrite(t *testing.T) {
+	tmpDir := t.TempDir()
+	statePath := filepath.Join(tmpDir, "ralph_state.json")
+
+	ch, stop, err := Watch(statePath)
+	if err != nil {
+		t.Fatalf("Watch failed for a not-yet-existing state file: %v", err)
+	}
+	defer stop()
+
+	time.Sleep(watchDebounce * 2)
+
+	if err := NewStore(statePath).Save(&State{Status: "running", CurrentCycle: 1}); err != nil {
+		t.Fatalf("Failed to save state: %v", err)
+	}
+
+	select {
+	case ev, ok := <-ch:
+		if !ok {
+			t.Fatal("Channel closed before any event arrived")
+		}
+		if ev.Type != StatusChanged {
+			t.Errorf("Expected StatusChanged for the first write, got %v", ev.Type)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Timed out waiting for the first write's event")
+	}
+}
+
+func TestWatchEmitsStatusChanged(t *testing.T) {
+	tmpDir := t.TempDir()
+	statePath := filepath.Join(tmpDir, "ralph_state.json")
+
+	store := NewStore(statePath)
+	if err := store.Save(&State{Status: "running"}); err != nil {
+		t.Fatalf("Failed to save initial state: %v", err)
+	}
+
+	ch, stop, err := Watch(statePath)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	defer stop()
+
+	time.Sleep(watchDebounce * 2)
+
+	if err := store.Save(&State{Status: "completed"}); err != nil {
+		t.Fatalf("Failed to save updated state: %v", err)
+	}
+
+	select {
+	case ev, ok := <-ch:
+		if !ok {
+			t.Fatal("Channel closed before any event arrived")
+		}
+		if ev.Type != StatusChanged {
+			t.Errorf("Expected StatusChanged, got %v", ev.Type)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Timed out waiting for StatusChanged event")
+	}
+}
+
+func TestWatchDebouncesRapidWrites(t *testing.T) {
+	tmpDir := t.TempDir()
+	statePath := filepath.Join(tmpDir, "ralph_state.json")
+
+	store := NewStore(statePath)
+	if err := store.Save(&State{Status: "running", CurrentCycle: 0}); err != nil {
+		t.Fatalf("Failed to save initial state: %v", err)
+	}
+
+	ch, stop, err := Watch(statePath)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	defer stop()
+
+	time.Sleep(watchDebounce * 2)
+
+	// Several quick saves within the debounce window should coalesce into a
+	// single diff against the state Watch last saw, not one event burst per
+	// write.
+	for i := 1; i <= 3; i++ {
+		if err := store.Save(&State{
+			Status:       "running",
+			CurrentCycle: i,
+			History:      make([]CycleSnapshot, i),
+		}); err != nil {
+			t.Fatalf("Failed to save state (write %d): %v", i, err)
+		}
+		time.Sleep(watchPollTick)
+	}
+
+	select {
+	case ev, ok := <-ch:
+		if !ok {
+			t.Fatal("Channel closed before any event arrived")
+		}
+		if ev.Type != CycleAdded {
+			t.Fatalf("Expected CycleAdded, got %v", ev.Type)
+		}
+		if len(ev.State.History) != 3 {
+			t.Errorf("Expected the coalesced event to reflect all 3 cycles, got %d", len(ev.State.History))
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Timed out waiting for the coalesced event")
+	}
+
+	select {
+	case ev, ok := <-ch:
+		if ok {
+			t.Errorf("Expected no further events from the coalesced writes, got %v", ev.Type)
+		}
+	case <-time.After(300 * time.Millisecond):
+		// No further event, as expected.
+	}
+}
+
+func TestWatchStopFlushesPendingEvent(t *testing.T) {
+	tmpDir := t.TempDir()
+	statePath := filepath.Join(tmpDir, "ralph_state.json")
+
+	store := NewStore(statePath)
+	if err := store.Save(&State{Status: "running"}); err != nil {
+		t.Fatalf("Failed to save initial state: %v", err)
+	}
+
+	ch, stop, err := Watch(statePath)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	if err := store.Save(&State{Status: "completed"}); err != nil {
+		t.Fatalf("Failed to save updated state: %v", err)
+	}
+
+	// A real caller (e.g. the tail command) drains ch from a separate
+	// goroutine while the main goroutine calls stop() on SIGINT; mirror that
+	// here so stop()'s flush send has somewhere to land.
+	var sawStatusChanged bool
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		for ev := range ch {
+			if ev.Type == StatusChanged {
+				sawStatusChanged = true
+			}
+		}
+	}()
+
+	// Give the write time to clear the debounce window, then stop
+	// immediately; stop should still flush the pending StatusChanged event
+	// before closing the channel.
+	time.Sleep(watchDebounce * 2)
+	stop()
+	<-drained
+
+	if !sawStatusChanged {
+		t.Error("Expected the final StatusChanged event to be flushed on stop")
+	}
+}
+
+func TestWatchStopIsSafeToCallConcurrentlyAndRepeatedly(t *testing.T) {
+	tmpDir := t.TempDir()
+	statePath := filepath.Join(tmpDir, "ralph_state.json")
+
+	if err := NewStore(statePath).Save(&State{Status: "running"}); err != nil {
+		t.Fatalf("Failed to save initial state: %v", err)
+	}
+
+	ch, stop, err := Watch(statePath)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		for range ch {
+		}
+	}()
+
+	// Mirrors a caller (like the tail command) where both a signal handler
+	// and a normal-completion check might call stop() around the same time;
+	// this must not panic on a double close and every call must return.
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stop()
+		}()
+	}
+	wg.Wait()
+	<-drained
+}